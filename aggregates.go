@@ -0,0 +1,106 @@
+package querybuilder
+
+// Count counts the rows where field is not null
+func Count(field Field) *aggregate {
+	return newAggregate("COUNT", field, Numeric)
+}
+
+// CountDistinct counts the distinct non null values of field
+func CountDistinct(field Field) *aggregate {
+	a := newAggregate("COUNT", field, Numeric)
+	a.distinct = true
+	return a
+}
+
+// CountAll counts every row
+func CountAll() Field {
+	return count
+}
+
+// Sum adds up the values of field
+func Sum(field Field) *aggregate {
+	return newAggregate("SUM", field, Numeric)
+}
+
+// Avg averages the values of field
+func Avg(field Field) *aggregate {
+	return newAggregate("AVG", field, Numeric)
+}
+
+// Min retrieves the lowest value of field, keeping its type
+func Min(field Field) *aggregate {
+	return newAggregate("MIN", field, field.Type())
+}
+
+// Max retrieves the highest value of field, keeping its type
+func Max(field Field) *aggregate {
+	return newAggregate("MAX", field, field.Type())
+}
+
+func newAggregate(function string, field Field, t Type) *aggregate {
+	return &aggregate{
+		function: function,
+		field:    field,
+		t:        t,
+	}
+}
+
+// aggregate an aggregate or window function over a field, the field being nil for
+// the functions taking no arguments
+type aggregate struct {
+	function  string
+	field     Field
+	distinct  bool
+	arguments []string
+	t         Type
+}
+
+func (a *aggregate) wrap(field string) string {
+	if a.distinct {
+		field = "DISTINCT " + field
+	}
+
+	for _, argument := range a.arguments {
+		field += ", " + argument
+	}
+
+	return a.function + "(" + field + ")"
+}
+
+// Name retrieves the aggregate expression
+func (a *aggregate) Name() string {
+	if a.field == nil {
+		return a.wrap("")
+	}
+
+	return a.wrap(a.field.Name())
+}
+
+// Table retrieves white, the result does not belong to any table
+func (a *aggregate) Table() string {
+	return ""
+}
+
+// Type retrieves the type of the aggregate result
+func (a *aggregate) Type() Type {
+	return a.t
+}
+
+// Over computes the function over the window rows instead of grouping them
+func (a *aggregate) Over(w *window) *windowed {
+	return &windowed{function: a, window: w}
+}
+
+// OverWindow computes the function over the rows of a window named in the query
+func (a *aggregate) OverWindow(name string) *windowed {
+	return &windowed{function: a, name: name}
+}
+
+func (a *aggregate) format(starter int, sc *scope) (string, []interface{}) {
+	if a.field == nil {
+		return a.wrap(""), nil
+	}
+
+	field, args := formatField(a.field, starter, sc)
+	return a.wrap(field), args
+}
@@ -0,0 +1,24 @@
+package querybuilder
+
+import "strings"
+
+// assignment sets a column to a value, formatted with the column type unless it is
+// a field or an expression
+type assignment struct {
+	field Field
+	value interface{}
+}
+
+type assignments []*assignment
+
+func (a assignments) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	formatted := make([]string, len(a))
+	for i, set := range a {
+		value, valueArgs := formatValue(set.value, set.field.Type(), starter+len(args), sc)
+		formatted[i] = set.field.Name() + " = " + value
+		args = append(args, valueArgs...)
+	}
+
+	return strings.Join(formatted, ", "), args
+}
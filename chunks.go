@@ -0,0 +1,83 @@
+package querybuilder
+
+// MaxParameters the most placeholders a single statement can bind in PostgreSQL
+const MaxParameters = 65535
+
+// Statement a query along with the arguments to be bound to its placeholders
+type Statement struct {
+	Query string
+	Args  []interface{}
+}
+
+// Chunks splits the insert in as many statements as needed for each one to bind less than
+// MaxParameters, sizing them from the arguments each row binds. Inserts not made of rows
+// of values result in a single statement. The statements can be executed in order within
+// a transaction to insert every row or none
+func (i *insert) Chunks() ([]Statement, error) {
+	if i.query != nil || i.defaults || len(i.fields) == 0 || len(i.rows) == 0 {
+		query, args, err := i.Done()
+		if err != nil {
+			return nil, err
+		}
+
+		return []Statement{{Query: query, Args: args}}, nil
+	}
+
+	// Expressions bind their own arguments, so rows can bind any number of them
+	available := MaxParameters - i.fixedArgs()
+	sc := newScope(nil, i.table)
+	var statements []Statement
+	start, bound := 0, 0
+	for r, row := range i.rows {
+		_, args := formatRows(i.fields, [][]interface{}{row}, false, 1, sc)
+		if len(args) > available {
+			return nil, ErrTooManyParameters
+		}
+
+		if bound+len(args) > available {
+			statement, err := i.chunk(start, r)
+			if err != nil {
+				return nil, err
+			}
+
+			statements = append(statements, statement)
+			start, bound = r, 0
+		}
+
+		bound += len(args)
+	}
+
+	statement, err := i.chunk(start, len(i.rows))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(statements, statement), nil
+}
+
+// chunk builds the statement inserting the rows from start to end
+func (i *insert) chunk(start, end int) (Statement, error) {
+	chunk := *i
+	chunk.rows = i.rows[start:end]
+	query, args, err := chunk.Done()
+	if err != nil {
+		return Statement{}, err
+	}
+
+	return Statement{Query: query, Args: args}, nil
+}
+
+// fixedArgs counts the arguments every chunk binds besides the ones of its rows
+func (i *insert) fixedArgs() int {
+	sc := newScope(nil, i.table)
+	_, args := i.with.format(1, sc)
+	count := len(args)
+
+	if i.conflict != nil {
+		_, args = i.conflict.format(1, sc)
+		count += len(args)
+	}
+
+	_, args = i.returning.format(1, sc)
+	return count + len(args)
+}
@@ -0,0 +1,55 @@
+package querybuilder
+
+type compoundType string
+
+const (
+	union     compoundType = "UNION"
+	unionAll  compoundType = "UNION ALL"
+	intersect compoundType = "INTERSECT"
+	except    compoundType = "EXCEPT"
+)
+
+type compounds []*compound
+
+// format returns every branch formatted numbering the placeholders from starter
+func (c compounds) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	var str string
+	for _, compound := range c {
+		formatted, compoundArgs := compound.format(starter+len(args), sc)
+		str += formatted
+		args = append(args, compoundArgs...)
+	}
+
+	return str, args
+}
+
+// compound a query whose rows get combined with the ones of the query before it
+type compound struct {
+	t     compoundType
+	query *sel
+}
+
+func (c *compound) format(starter int, sc *scope) (string, []interface{}) {
+	var query string
+	var args []interface{}
+	if c.grouped() {
+		query, args = c.query.nested(starter, sc.detached())
+	} else {
+		query, args = c.query.format(starter, sc.detached())
+	}
+
+	return " " + string(c.t) + " " + query, args
+}
+
+// grouped tells whether the branch needs parentheses. Its CTEs, sorts, limits, locks and
+// set operations are its own, they must not apply to the whole result nor bind to the
+// operations around it
+func (c *compound) grouped() bool {
+	q := c.query
+	if q.with != nil || len(q.compounds) > 0 {
+		return true
+	}
+
+	return len(q.orders) > 0 || q.limit.limit != 0 || len(q.locks) > 0
+}
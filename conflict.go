@@ -0,0 +1,123 @@
+package querybuilder
+
+import "strings"
+
+type conflictAction string
+
+const (
+	doNothing conflictAction = "DO NOTHING"
+	doUpdate  conflictAction = "DO UPDATE"
+)
+
+// Excluded references the value proposed for insertion of field, to be used in the
+// ON CONFLICT DO UPDATE assignments and conditions
+func Excluded(field Field) *excluded {
+	return &excluded{field: field}
+}
+
+type excluded struct {
+	field Field
+}
+
+// Name retrieves the name of the column
+func (e *excluded) Name() string {
+	return e.field.Name()
+}
+
+// Table retrieves the special excluded table
+func (e *excluded) Table() string {
+	return "EXCLUDED"
+}
+
+// Type retrieves the type of the column
+func (e *excluded) Type() Type {
+	return e.field.Type()
+}
+
+func (e *excluded) format(starter int, sc *scope) (string, []interface{}) {
+	return "EXCLUDED." + e.field.Name(), nil
+}
+
+type conflict struct {
+	target     Fields
+	constraint string
+	action     conflictAction
+	sets       assignments
+	filter     *Filters
+	father     *insert
+}
+
+func (c *conflict) format(starter int, sc *scope) (string, []interface{}) {
+	if c.action == "" {
+		c.action = doNothing
+	}
+
+	str := " ON CONFLICT"
+	switch {
+	case c.constraint != "":
+		str += " ON CONSTRAINT " + c.constraint
+	case len(c.target) > 0:
+		str += " (" + strings.Join(c.target.Names(), ", ") + ")"
+	case c.action == doUpdate:
+		sc.fail(ErrConflictTarget)
+	}
+
+	str += " " + string(c.action)
+	if c.action != doUpdate {
+		return str, nil
+	}
+
+	if len(c.sets) == 0 {
+		sc.fail(ErrMissingSet)
+	}
+
+	// The rows proposed for insertion are in scope along with the target ones
+	sc = newScope(sc, NewTable("EXCLUDED"))
+	sets, args := c.sets.format(starter, sc)
+	str += " SET " + sets
+
+	if c.filter != nil {
+		where, whereArgs := c.filter.format(starter+len(args), sc)
+		if where != "" {
+			str += " WHERE " + where
+			args = append(args, whereArgs...)
+		}
+	}
+
+	return str, args
+}
+
+// DoNothing skips the rows in conflict
+func (c *conflict) DoNothing() *insert {
+	c.action = doNothing
+	return c.father
+}
+
+// DoUpdate updates the existing rows in conflict instead
+func (c *conflict) DoUpdate() *conflictUpdate {
+	c.action = doUpdate
+	return &conflictUpdate{
+		insert:   c.father,
+		conflict: c,
+	}
+}
+
+// conflictUpdate the assignments of an ON CONFLICT DO UPDATE, the insert can be
+// carried on from it
+type conflictUpdate struct {
+	*insert
+	conflict *conflict
+}
+
+// Set sets field to value on the rows in conflict. Value can be a parameter, a Field,
+// an expression or Excluded to take the one proposed for insertion
+func (u *conflictUpdate) Set(field Field, value interface{}) *conflictUpdate {
+	u.conflict.sets = append(u.conflict.sets, &assignment{field: field, value: value})
+	return u
+}
+
+// Where updates only the rows in conflict matching the filters
+func (u *conflictUpdate) Where(filters Filters) *conflictUpdate {
+	u.conflict.filter = &filters
+	return u
+}
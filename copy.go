@@ -0,0 +1,284 @@
+package querybuilder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// CopyFormat the format the rows of a COPY are written in
+type CopyFormat string
+
+const (
+	// CopyText tab separated values, the default format
+	CopyText CopyFormat = "text"
+	// CopyCSV comma separated values
+	CopyCSV CopyFormat = "csv"
+	// CopyBinary the binary format of PostgreSQL
+	CopyBinary CopyFormat = "binary"
+)
+
+// CopyFrom initiates a COPY loading rows from the client into table
+func CopyFrom(table Table) *copyStatement {
+	return &copyStatement{
+		table: table,
+		from:  true,
+	}
+}
+
+// CopyTo initiates a COPY sending rows to the client, source being a table name, a Table
+// or a select. As COPY can not bind parameters the select can not have any argument
+func CopyTo(source interface{}) *copyStatement {
+	return &copyStatement{
+		table: toTable(source),
+	}
+}
+
+type copyStatement struct {
+	table     Table
+	fields    Fields
+	from      bool
+	format    CopyFormat
+	header    bool
+	delimiter string
+	null      *string
+}
+
+// Columns sets the columns copied, their types drive how Encode writes the values
+func (c *copyStatement) Columns(fields ...Field) *copyStatement {
+	c.fields = append(c.fields, fields...)
+	return c
+}
+
+// WithFormat sets the format of the rows, text if not specified
+func (c *copyStatement) WithFormat(format CopyFormat) *copyStatement {
+	c.format = format
+	return c
+}
+
+// Header adds a first line with the column names
+func (c *copyStatement) Header() *copyStatement {
+	c.header = true
+	return c
+}
+
+// Delimiter sets the character separating the values of a row
+func (c *copyStatement) Delimiter(delimiter string) *copyStatement {
+	c.delimiter = delimiter
+	return c
+}
+
+// Null sets the string representing a null value
+func (c *copyStatement) Null(null string) *copyStatement {
+	c.null = &null
+	return c
+}
+
+// Done returns the statement, which never has arguments
+func (c *copyStatement) Done() (string, []interface{}, error) {
+	if c.table.empty() {
+		return "", nil, ErrMissingTable
+	}
+
+	if !c.validSource() {
+		return "", nil, ErrCopySource
+	}
+
+	// The query of a COPY goes between parentheses with no alias
+	var source string
+	var args []interface{}
+	sc := newScope(nil)
+	if c.table.query != nil {
+		source, args = c.table.query.nested(1, sc)
+	} else {
+		source, args = c.table.format(1, sc)
+	}
+
+	if err := sc.error(); err != nil {
+		return "", nil, err
+	}
+
+	if len(args) > 0 {
+		return "", nil, ErrCopyParameters
+	}
+
+	query := "COPY " + source
+	if len(c.fields) > 0 {
+		query += " (" + strings.Join(c.fields.Names(), ", ") + ")"
+	}
+
+	if c.from {
+		query += " FROM STDIN"
+	} else {
+		query += " TO STDOUT"
+	}
+
+	return query + c.options() + ";", nil, nil
+}
+
+// validSource checks the source is an unaliased table, or a select whose own columns are
+// copied to the client
+func (c *copyStatement) validSource() bool {
+	if c.table.as != "" || c.table.values != nil {
+		return false
+	}
+
+	return c.table.query == nil || (!c.from && len(c.fields) == 0)
+}
+
+func (c *copyStatement) options() string {
+	var options []string
+	if c.format != "" {
+		options = append(options, "FORMAT "+string(c.format))
+	}
+
+	if c.header {
+		options = append(options, "HEADER true")
+	}
+
+	if c.delimiter != "" {
+		options = append(options, "DELIMITER "+quote(c.delimiter))
+	}
+
+	if c.null != nil {
+		options = append(options, "NULL "+quote(*c.null))
+	}
+
+	if len(options) == 0 {
+		return ""
+	}
+
+	return " WITH (" + strings.Join(options, ", ") + ")"
+}
+
+// Encode writes the rows in the text or csv format of the statement, each value as
+// expected by the type of its column
+func (c *copyStatement) Encode(w io.Writer, rows ...[]interface{}) error {
+	if c.format == CopyBinary {
+		return ErrCopyEncoding
+	}
+
+	if c.header {
+		if err := c.writeRow(w, c.fields.Names(), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if len(c.fields) > 0 && len(row) != len(c.fields) {
+			return ErrValuesCount
+		}
+
+		values := make([]string, len(row))
+		nulls := make([]bool, len(row))
+		for i, value := range row {
+			t := String
+			if len(c.fields) > 0 {
+				t = c.fields[i].Type()
+			}
+
+			values[i], nulls[i] = encodeValue(value, t)
+		}
+
+		if err := c.writeRow(w, values, nulls); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *copyStatement) writeRow(w io.Writer, values []string, nulls []bool) error {
+	csv := c.format == CopyCSV
+	delimiter := c.delimiter
+	if delimiter == "" {
+		delimiter = "\t"
+		if csv {
+			delimiter = ","
+		}
+	}
+
+	null := `\N`
+	if csv {
+		null = ""
+	}
+
+	if c.null != nil {
+		null = *c.null
+	}
+
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		switch {
+		case nulls != nil && nulls[i]:
+			formatted[i] = null
+		case csv:
+			formatted[i] = csvEscape(value, delimiter, null)
+		default:
+			formatted[i] = textEscape(value, delimiter)
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(formatted, delimiter)+"\n")
+	return err
+}
+
+// encodeValue writes value as text, telling whether it is null
+func encodeValue(value interface{}, t Type) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, false
+	case []byte:
+		return `\x` + hex.EncodeToString(v), false
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999Z07:00"), false
+	case bool:
+		if v {
+			return "t", false
+		}
+
+		return "f", false
+	}
+
+	// Dates are bound as epochs through to_timestamp, which COPY does not go through
+	if t == Date {
+		switch v := value.(type) {
+		case int:
+			return encodeValue(time.Unix(int64(v), 0).UTC(), t)
+		case int64:
+			return encodeValue(time.Unix(v, 0).UTC(), t)
+		case float64:
+			return encodeValue(time.Unix(0, int64(v*float64(time.Second))).UTC(), t)
+		}
+	}
+
+	return fmt.Sprint(value), false
+}
+
+var textReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func textEscape(value string, delimiter string) string {
+	value = textReplacer.Replace(value)
+	if delimiter != "\t" {
+		value = strings.ReplaceAll(value, delimiter, `\`+delimiter)
+	}
+
+	return value
+}
+
+func csvEscape(value string, delimiter string, null string) string {
+	if value != null && !strings.ContainsAny(value, delimiter+"\"\n\r") {
+		return value
+	}
+
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+// quote quotes a string literal
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,108 @@
+package querybuilder_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gonzispina/querybuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopy(test *testing.T) {
+	payments := querybuilder.NewTable("payments")
+
+	test.Run("Copy from stdin", func(t *testing.T) {
+		query, args, err := querybuilder.CopyFrom(payments).
+			Columns(id, amount, dueDate).
+			WithFormat(querybuilder.CopyCSV).
+			Header().
+			Delimiter(";").
+			Null("NULL").
+			Done()
+
+		expected := "COPY payments (id, amount, due_date) FROM STDIN WITH (FORMAT csv, HEADER true, DELIMITER ';', NULL 'NULL');"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Empty(t, args)
+	})
+
+	test.Run("Copy a query to stdout", func(t *testing.T) {
+		query, _, err := querybuilder.CopyTo(querybuilder.Select(id, amount).From("payments").OrderBy(id).Asc()).
+			WithFormat(querybuilder.CopyBinary).
+			Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "COPY (SELECT id, amount FROM payments ORDER BY id ASC) TO STDOUT WITH (FORMAT binary);", query)
+	})
+
+	test.Run("Copy a table to stdout", func(t *testing.T) {
+		query, _, err := querybuilder.CopyTo("payments").Columns(id).Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "COPY payments (id) TO STDOUT;", query)
+	})
+
+	test.Run("Copy a query with arguments", func(t *testing.T) {
+		_, _, err := querybuilder.CopyTo(querybuilder.Select().From("payments").Where(*querybuilder.New().Field(isActive).EqualTo(true))).Done()
+		assert.Equal(t, querybuilder.ErrCopyParameters, err)
+	})
+
+	test.Run("Copy invalid sources", func(t *testing.T) {
+		query := querybuilder.Select(id).From("payments")
+		statements := []interface {
+			Done() (string, []interface{}, error)
+		}{
+			querybuilder.CopyTo(querybuilder.Subquery(query).As("x")),
+			querybuilder.CopyTo(query).Columns(id),
+			querybuilder.CopyFrom(querybuilder.Subquery(query)),
+			querybuilder.CopyFrom(payments.As("p")),
+			querybuilder.CopyTo(querybuilder.Values(querybuilder.Fields{id}, []interface{}{"1234"})),
+		}
+
+		for _, statement := range statements {
+			_, _, err := statement.Done()
+			assert.Equal(t, querybuilder.ErrCopySource, err)
+		}
+	})
+
+	test.Run("Encode text rows", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := querybuilder.CopyFrom(payments).
+			Columns(id, amount, dueDate, isActive).
+			Encode(&buffer,
+				[]interface{}{"12\t34", 10.5, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), true},
+				[]interface{}{"a\\b\nc", nil, 1577872800, false},
+			)
+
+		expected := "12\\t34\t10.5\t2020-01-01 10:00:00Z\tt\n" +
+			"a\\\\b\\nc\t\\N\t2020-01-01 10:00:00Z\tf\n"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	test.Run("Encode csv rows", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := querybuilder.CopyFrom(payments).
+			Columns(id, amount).
+			WithFormat(querybuilder.CopyCSV).
+			Header().
+			Encode(&buffer,
+				[]interface{}{`say "hi", bye`, 10},
+				[]interface{}{"", nil},
+			)
+
+		expected := "id,amount\n" +
+			"\"say \"\"hi\"\", bye\",10\n" +
+			"\"\",\n"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	test.Run("Encode binary rows", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := querybuilder.CopyFrom(payments).WithFormat(querybuilder.CopyBinary).Encode(&buffer, []interface{}{"1"})
+		assert.Equal(t, querybuilder.ErrCopyEncoding, err)
+	})
+}
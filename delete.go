@@ -0,0 +1,69 @@
+package querybuilder
+
+type delete struct {
+	baseQuery
+	returning
+	using  tables
+	filter *Filters
+	all    bool
+}
+
+// Using adds tables to read from, either table names, Tables, Subqueries or Values. Their
+// rows are joined to the deleted ones through the Where filters
+func (d *delete) Using(sources ...interface{}) *delete {
+	d.using = append(d.using, toTables(sources...)...)
+	return d
+}
+
+// Where deletes only the rows matching the filters
+func (d *delete) Where(filters Filters) *delete {
+	d.filter = &filters
+	return d
+}
+
+// AllRows allows the query to delete every row, as it fails without filters otherwise
+func (d *delete) AllRows() *delete {
+	d.all = true
+	return d
+}
+
+// Returning gives back the given fields of the deleted rows
+func (d *delete) Returning(fields ...Field) *delete {
+	d.returning.add(fields...)
+	return d
+}
+
+// Done returns the query and the arguments to be bound to its placeholders
+func (d *delete) Done() (string, []interface{}, error) {
+	return done(d)
+}
+
+// format builds the query numbering its placeholders from starter
+func (d *delete) format(starter int, parent *scope) (string, []interface{}) {
+	if !d.hasTable() {
+		parent.fail(ErrMissingTable)
+		return "", nil
+	}
+
+	sc := newScope(parent, append(tables{d.table}, d.using...)...)
+	query, args := d.with.format(starter, sc)
+	table, tableArgs := d.table.format(starter+len(args), sc)
+	query += "DELETE FROM " + table
+	args = append(args, tableArgs...)
+
+	if len(d.using) > 0 {
+		using, usingArgs := d.using.format(starter+len(args), sc)
+		query += " USING " + using
+		args = append(args, usingArgs...)
+	}
+
+	where, whereArgs := formatWhere(d.filter, d.all, starter+len(args), sc)
+	query += where
+	args = append(args, whereArgs...)
+
+	returned, returnedArgs := d.returning.format(starter+len(args), sc)
+	query += returned
+	args = append(args, returnedArgs...)
+
+	return query, args
+}
@@ -0,0 +1,74 @@
+package querybuilder_test
+
+import (
+	"testing"
+
+	"github.com/gonzispina/querybuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteQuery(test *testing.T) {
+	payments := querybuilder.NewTable("payments")
+
+	test.Run("Delete with filters", func(t *testing.T) {
+		deletion := querybuilder.DeleteFrom(payments).
+			Where(*querybuilder.New().Field(isActive).EqualTo(false).And().Field(dueDate).LesserThan("2020-01-01")).
+			Returning(id)
+
+		query, args, err := deletion.Done()
+
+		expected := "DELETE FROM payments WHERE (is_active = $1 AND due_date < to_timestamp($2)) RETURNING id;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{false, "2020-01-01"}, args)
+		assert.Equal(t, []string{"id"}, deletion.ResultColumns())
+	})
+
+	test.Run("Delete using other tables", func(t *testing.T) {
+		query, args, err := querybuilder.With("old", querybuilder.Select(usersID).From("users").Where(*querybuilder.New().Field(usersCreated).LesserThan("2020-01-01"))).
+			DeleteFrom(payments).
+			Using("old").
+			Where(*querybuilder.New().Field(userID).EqualTo(querybuilder.Qualify(usersID, "old")).And().Field(amount).EqualTo(0)).
+			Done()
+
+		expected := "WITH old AS (SELECT id FROM users WHERE (created_at < to_timestamp($1)))" +
+			" DELETE FROM payments USING old WHERE (payments.user_id = old.id AND payments.amount = $2);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"2020-01-01", 0}, args)
+	})
+
+	test.Run("Delete using a values list", func(t *testing.T) {
+		removed := querybuilder.Values(querybuilder.Fields{id}, []interface{}{"1234"}, []interface{}{"5678"}).As("removed")
+
+		query, args, err := querybuilder.DeleteFrom(payments).
+			Using(removed).
+			Where(*querybuilder.New().Field(id).EqualTo(querybuilder.Qualify(id, "removed")).And().Field(isActive).EqualTo(false)).
+			Done()
+
+		expected := "DELETE FROM payments USING (VALUES ($1), ($2)) AS removed (id)" +
+			" WHERE (payments.id = removed.id AND payments.is_active = $3);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", "5678", false}, args)
+	})
+
+	test.Run("Delete without filters", func(t *testing.T) {
+		_, _, err := querybuilder.DeleteFrom(payments).Done()
+		assert.Equal(t, querybuilder.ErrMissingWhere, err)
+	})
+
+	test.Run("Delete with filters rendering empty", func(t *testing.T) {
+		_, _, err := querybuilder.DeleteFrom(payments).Where(*querybuilder.New().Field(userID).In()).Done()
+		assert.Equal(t, querybuilder.ErrMissingWhere, err)
+	})
+
+	test.Run("Delete all rows", func(t *testing.T) {
+		query, args, err := querybuilder.DeleteFrom(payments).AllRows().Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "DELETE FROM payments;", query)
+		assert.Empty(t, args)
+	})
+}
@@ -0,0 +1,50 @@
+package querybuilder
+
+import "errors"
+
+var (
+	// ErrMissingTable the query has no table to work on
+	ErrMissingTable = errors.New("querybuilder: missing table")
+	// ErrInvalidTable the table is neither a name, a Table nor a select
+	ErrInvalidTable = errors.New("querybuilder: a table must be a name, a Table or a select")
+	// ErrMissingAlias the subquery or VALUES list is used as a table without an alias
+	ErrMissingAlias = errors.New("querybuilder: subqueries and VALUES lists used as tables must be aliased")
+	// ErrMissingSubquery the subquery of a condition is nil
+	ErrMissingSubquery = errors.New("querybuilder: missing subquery")
+	// ErrInvalidValue the value is a table or statement that can not be compared nor assigned
+	ErrInvalidValue = errors.New("querybuilder: values must be parameters, fields, expressions or selects")
+	// ErrAmbiguousTable the field belongs to a table read under several aliases
+	ErrAmbiguousTable = errors.New("querybuilder: ambiguous table, use Qualify to pick one of its aliases")
+	// ErrDefaultValues the insert of DEFAULT VALUES also has columns, rows or a select
+	ErrDefaultValues = errors.New("querybuilder: DEFAULT VALUES takes no columns, rows nor select")
+	// ErrMissingValues the insert has no rows to insert
+	ErrMissingValues = errors.New("querybuilder: missing values to insert")
+	// ErrValuesCount a row to insert does not have a value for each column
+	ErrValuesCount = errors.New("querybuilder: each row must have one value per column")
+	// ErrMissingWhere the update or delete would affect every row without AllRows
+	ErrMissingWhere = errors.New("querybuilder: missing filters, call AllRows to affect every row")
+	// ErrMissingJoinCondition the join has neither ON conditions nor USING columns
+	ErrMissingJoinCondition = errors.New("querybuilder: only CROSS and NATURAL joins can go without ON or USING")
+	// ErrMissingSet there are no columns to set
+	ErrMissingSet = errors.New("querybuilder: missing columns to set")
+	// ErrTooManyParameters a single row binds more parameters than a statement can
+	ErrTooManyParameters = errors.New("querybuilder: too many parameters for a single statement")
+	// ErrCopyParameters the query of a COPY has arguments, which COPY can not bind
+	ErrCopyParameters = errors.New("querybuilder: COPY can not bind parameters")
+	// ErrCopySource COPY takes no aliases, no VALUES lists and only copies selects to the client
+	ErrCopySource = errors.New("querybuilder: COPY requires an unaliased table, or a select without columns to copy to STDOUT")
+	// ErrCopyEncoding the rows can only be encoded in the text and csv formats
+	ErrCopyEncoding = errors.New("querybuilder: only text and csv rows can be encoded")
+	// ErrConflictTarget ON CONFLICT DO UPDATE needs the conflicting columns or constraint
+	ErrConflictTarget = errors.New("querybuilder: ON CONFLICT DO UPDATE requires a conflict target")
+	// ErrIncompleteMerge the merge lacks its source, its ON conditions or any WHEN clause
+	ErrIncompleteMerge = errors.New("querybuilder: MERGE requires a source, ON conditions and at least one WHEN clause")
+	// ErrColumnCount the queries of a set operation do not return the same number of columns
+	ErrColumnCount = errors.New("querybuilder: set operation queries must return the same number of columns")
+	// ErrLockNotAllowed the query can not lock its rows, as they do not map to the table ones
+	ErrLockNotAllowed = errors.New("querybuilder: row locking is not allowed with DISTINCT, GROUP BY, HAVING, WINDOW, aggregates or set operations")
+	// ErrUndeclaredWindow a function is computed over a window not named in the query
+	ErrUndeclaredWindow = errors.New("querybuilder: windows must be named through Window before being referenced")
+	// ErrDistinctOnOrder the DISTINCT ON expressions do not match the leftmost ORDER BY ones
+	ErrDistinctOnOrder = errors.New("querybuilder: DISTINCT ON expressions must match the leftmost ORDER BY expressions")
+)
@@ -0,0 +1,40 @@
+package querybuilder
+
+func newExistsFilter(query *sel, not bool, father *group) *exists {
+	return &exists{
+		query: query,
+		not:   not,
+		group: father,
+	}
+}
+
+// exists checks whether a subquery returns any row
+type exists struct {
+	logical logicalType
+	query   *sel
+	not     bool
+	group   *group
+}
+
+func (e *exists) addLogical(operator logicalType) {
+	e.logical = operator
+}
+
+func (e *exists) father() *group {
+	return e.group
+}
+
+func (e *exists) format(starter int, sc *scope) (string, []interface{}) {
+	if e.query == nil {
+		sc.fail(ErrMissingSubquery)
+		return "", nil
+	}
+
+	query, args := e.query.nested(starter, sc)
+	str := "EXISTS " + query
+	if e.not {
+		str = "NOT " + str
+	}
+
+	return e.logical.format(str), args
+}
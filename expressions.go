@@ -0,0 +1,63 @@
+package querybuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// expression is anything that formats itself into sql, binding its own arguments
+type expression interface {
+	format(starter int, sc *scope) (string, []interface{})
+}
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// Expr creates a raw sql expression usable wherever a Field is expected. Its placeholders
+// are written starting from $1 and get renumbered to fit in the query they end up in
+func Expr(sql string, args ...interface{}) *expr {
+	return &expr{
+		sql:  sql,
+		args: args,
+	}
+}
+
+type expr struct {
+	sql  string
+	args []interface{}
+}
+
+// Name retrieves the raw expression
+func (e *expr) Name() string {
+	return e.sql
+}
+
+// Table retrieves white
+func (e *expr) Table() string {
+	return ""
+}
+
+// Type if not specified retrieves a string
+func (e *expr) Type() Type {
+	return String
+}
+
+func (e *expr) format(starter int, sc *scope) (string, []interface{}) {
+	sql := placeholder.ReplaceAllStringFunc(e.sql, func(match string) string {
+		n, _ := strconv.Atoi(match[1:])
+		return fmt.Sprintf("$%v", n+starter-1)
+	})
+
+	return sql, e.args
+}
+
+// Default sets a column to its default value, to be used among the values of a row
+func Default() defaultValue {
+	return defaultValue{}
+}
+
+type defaultValue struct{}
+
+func (d defaultValue) format(starter int, sc *scope) (string, []interface{}) {
+	return "DEFAULT", nil
+}
@@ -1,7 +1,6 @@
 package querybuilder
 
 import (
-	"fmt"
 	"strings"
 )
 
@@ -17,16 +16,129 @@ type Fields []Field
 
 // Format all the columns to get queried
 func (c Fields) Format() string {
-	cols := make([]string, len(c))
+	cols := make([]string, 0, len(c))
 	for _, f := range c {
-		table := f.Table()
-		if table != "" {
-			table += table + "."
+		cols = append(cols, column(f))
+	}
+	return strings.Join(cols, ", ")
+}
+
+// format formats the columns as part of a query numbering the placeholders from starter
+func (c Fields) format(starter int, sc *scope) (string, []interface{}) {
+	return c.formatWith(starter, sc, formatField)
+}
+
+// selection formats the columns of a select list, where the aliases get defined
+func (c Fields) selection(starter int, sc *scope) (string, []interface{}) {
+	return c.formatWith(starter, sc, func(f Field, starter int, sc *scope) (string, []interface{}) {
+		if a, ok := f.(Aliased); ok {
+			return a.define(starter, sc)
 		}
 
-		cols = append(cols, fmt.Sprintf("%s%s", table, f.Name()))
+		return formatField(f, starter, sc)
+	})
+}
+
+func (c Fields) formatWith(starter int, sc *scope, format func(Field, int, *scope) (string, []interface{})) (string, []interface{}) {
+	var args []interface{}
+	cols := make([]string, 0, len(c))
+	for _, f := range c {
+		formatted, fieldArgs := format(f, starter+len(args), sc)
+		cols = append(cols, formatted)
+		args = append(args, fieldArgs...)
+	}
+	return strings.Join(cols, ", "), args
+}
+
+// Names retrieves the name of every field, the alias for the aliased ones
+func (c Fields) Names() []string {
+	names := make([]string, len(c))
+	for i, f := range c {
+		names[i] = f.Name()
+	}
+	return names
+}
+
+// formatField formats a single field, either a column or an expression
+func formatField(f Field, starter int, sc *scope) (string, []interface{}) {
+	if e, ok := f.(expression); ok {
+		return e.format(starter, sc)
+	}
+
+	return sc.column(f), nil
+}
+
+// column returns the field qualified by its table
+func column(f Field) string {
+	if f.Table() == "" {
+		return f.Name()
+	}
+
+	return f.Table() + "." + f.Name()
+}
+
+// Qualify binds the field to the given table or alias. Useful to tell apart the
+// columns of a table joined more than once
+func Qualify(field Field, table string) Field {
+	return qualifiedField{
+		Field: field,
+		table: table,
 	}
-	return strings.Join(cols, ", ")
+}
+
+type qualifiedField struct {
+	Field
+	table string
+}
+
+// Table retrieves the table or alias the field was bound to
+func (q qualifiedField) Table() string {
+	return q.table
+}
+
+// As renames the field in the select list
+func As(field Field, alias string) Aliased {
+	return Aliased{
+		field: field,
+		alias: alias,
+	}
+}
+
+// Aliased a field renamed in the select list. ORDER BY and GROUP BY reference it by its
+// alias, filters by the aliased expression
+type Aliased struct {
+	field Field
+	alias string
+}
+
+// Name retrieves the alias
+func (a Aliased) Name() string {
+	return a.alias
+}
+
+// Table retrieves white, the alias belongs to the result
+func (a Aliased) Table() string {
+	return ""
+}
+
+// Type retrieves the type of the aliased field
+func (a Aliased) Type() Type {
+	return a.field.Type()
+}
+
+// Field retrieves the field behind the alias
+func (a Aliased) Field() Field {
+	return a.field
+}
+
+func (a Aliased) format(starter int, sc *scope) (string, []interface{}) {
+	return a.alias, nil
+}
+
+// define formats the field followed by its alias
+func (a Aliased) define(starter int, sc *scope) (string, []interface{}) {
+	field, args := formatField(a.field, starter, sc)
+	return field + " AS " + a.alias, args
 }
 
 type commonField string
@@ -48,5 +160,9 @@ func (c commonField) Table() string {
 
 // Type if not specified retrieves a string
 func (c commonField) Type() Type {
+	if c == count {
+		return Numeric
+	}
+
 	return String
 }
\ No newline at end of file
@@ -3,7 +3,7 @@ package querybuilder
 type filter interface {
 	addLogical(operator logicalType)
 	father() *group
-	format(starter int) (string, []interface{})
+	format(starter int, sc *scope) (string, []interface{})
 }
 
 func New() *Filters {
@@ -35,6 +35,23 @@ func (f *Filters) Field(field Field) *single {
 	return newFilter
 }
 
+// Exists adds a condition on the subquery returning any row
+func (f *Filters) Exists(query *sel) *Filters {
+	return f.addExists(query, false)
+}
+
+// NotExists adds a condition on the subquery returning no rows
+func (f *Filters) NotExists(query *sel) *Filters {
+	return f.addExists(query, true)
+}
+
+func (f *Filters) addExists(query *sel, not bool) *Filters {
+	newFilter := newExistsFilter(query, not, f.currentGroup)
+	newFilter.addLogical(f.lastLogical)
+	f.currentGroup.addFilter(newFilter)
+	return f
+}
+
 // OpenBracket opens a bracket
 func (f *Filters) OpenBracket() *Filters {
 	newGroup := newFilterGroup(f.currentGroup)
@@ -66,6 +83,15 @@ func (f *Filters) Or() *Filters {
 
 // Format returns the filters formatted
 func (f *Filters) Format() (string, []interface{}) {
-	return f.mainGroup.format(1)
+	return f.format(1, nil)
+}
+
+// format returns the filters formatted numbering the placeholders from starter
+func (f *Filters) format(starter int, sc *scope) (string, []interface{}) {
+	if f.mainGroup == nil {
+		return "", nil
+	}
+
+	return f.mainGroup.format(starter, sc)
 }
 
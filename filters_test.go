@@ -42,6 +42,31 @@ func (f field) Type() qb.Type {
 	return t
 }
 
+type userField string
+
+const (
+	usersID      userField = "id"
+	usersCreated userField = "created_at"
+)
+
+// Name returns a proper string representing the column name
+func (f userField) Name() string {
+	return string(f)
+}
+
+// Table returns the table name of the field
+func (f userField) Table() string {
+	return "users"
+}
+
+// Type returns the field type
+func (f userField) Type() qb.Type {
+	if f == usersCreated {
+		return qb.Date
+	}
+
+	return qb.String
+}
 
 func TestFilters(test *testing.T) {
 	test.Run("Equal String Type", func(t *testing.T) {
@@ -235,4 +260,28 @@ func TestFilters(test *testing.T) {
 		assert.Equal(t, "(user_id IS NOT NULL)", got)
 		assert.Equal(t, 0, len(args))
 	})
+
+	test.Run("Equal to another column", func(t *testing.T) {
+		got, args := qb.New().
+			Field(userID).EqualTo(usersID).
+			And().
+			Field(dueDate).GreaterThan(usersCreated).
+			Format()
+
+		assert.Equal(t, "(user_id = users.id AND due_date > users.created_at)", got)
+		assert.Equal(t, 0, len(args))
+	})
+
+	test.Run("Columns and values are numbered together", func(t *testing.T) {
+		got, args := qb.New().
+			Field(dueDate).Between(usersCreated, "2020-01-01").
+			And().
+			Field(amount).EqualTo(qb.Expr("$1 * $2", 2, 3)).
+			And().
+			Field(userID).In(usersID, "1234").
+			Format()
+
+		assert.Equal(t, "(due_date BETWEEN (users.created_at AND to_timestamp($1)) AND amount = $2 * $3 AND user_id IN (users.id, $4))", got)
+		assert.Equal(t, []interface{}{"2020-01-01", 2, 3, "1234"}, args)
+	})
 }
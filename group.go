@@ -29,11 +29,11 @@ func (g *group) father() *group {
 	return g.group
 }
 
-func (g *group) format(starter int) (string, []interface{}) {
+func (g *group) format(starter int, sc *scope) (string, []interface{}) {
 	var args []interface{}
 	var formatted []string
 	for _, filter := range g.filters {
-		filterFormatted, filterArgs := filter.format(starter)
+		filterFormatted, filterArgs := filter.format(starter, sc)
 		if filterFormatted == "" {
 			continue
 		}
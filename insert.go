@@ -0,0 +1,143 @@
+package querybuilder
+
+import "strings"
+
+type insert struct {
+	baseQuery
+	returning
+	fields   Fields
+	rows     [][]interface{}
+	query    *sel
+	defaults bool
+	conflict *conflict
+}
+
+// Columns sets the columns the values are inserted into
+func (i *insert) Columns(fields ...Field) *insert {
+	i.fields = append(i.fields, fields...)
+	return i
+}
+
+// Values adds a row of values, one for each column. Call it again for each row to insert
+func (i *insert) Values(values ...interface{}) *insert {
+	i.rows = append(i.rows, values)
+	return i
+}
+
+// FromSelect inserts the rows returned by query, which must return one value per column
+func (i *insert) FromSelect(query *sel) *insert {
+	i.query = query
+	return i
+}
+
+// DefaultValues inserts a single row made of the default value of every column
+func (i *insert) DefaultValues() *insert {
+	i.defaults = true
+	return i
+}
+
+// OnConflict handles the rows conflicting with existing ones on the given unique columns.
+// DO NOTHING takes no columns to handle any conflict
+func (i *insert) OnConflict(fields ...Field) *conflict {
+	i.conflict = &conflict{target: fields, father: i}
+	return i.conflict
+}
+
+// OnConflictOnConstraint handles the rows violating the given constraint
+func (i *insert) OnConflictOnConstraint(name string) *conflict {
+	i.conflict = &conflict{constraint: name, father: i}
+	return i.conflict
+}
+
+// Returning gives back the given fields of the inserted rows
+func (i *insert) Returning(fields ...Field) *insert {
+	i.returning.add(fields...)
+	return i
+}
+
+// Done returns the query and the arguments to be bound to its placeholders
+func (i *insert) Done() (string, []interface{}, error) {
+	return done(i)
+}
+
+// format builds the query numbering its placeholders from starter
+func (i *insert) format(starter int, parent *scope) (string, []interface{}) {
+	if !i.hasTable() {
+		parent.fail(ErrMissingTable)
+		return "", nil
+	}
+
+	sc := newScope(parent, i.table)
+	query, args := i.with.format(starter, sc)
+	table, tableArgs := i.table.format(starter+len(args), sc)
+	query += "INSERT INTO " + table
+	args = append(args, tableArgs...)
+
+	if len(i.fields) > 0 {
+		query += " (" + strings.Join(i.fields.Names(), ", ") + ")"
+	}
+
+	switch {
+	case i.defaults:
+		if len(i.fields) > 0 || len(i.rows) > 0 || i.query != nil {
+			sc.fail(ErrDefaultValues)
+		}
+
+		query += " DEFAULT VALUES"
+	case i.query != nil:
+		if !i.query.hasWildcard() && len(i.fields) > 0 && len(i.query.fields) != len(i.fields) {
+			sc.fail(ErrValuesCount)
+		}
+
+		selected, selectedArgs := i.query.format(starter+len(args), sc.detached())
+		query += " " + selected
+		args = append(args, selectedArgs...)
+	case len(i.rows) == 0:
+		sc.fail(ErrMissingValues)
+	default:
+		values, valuesArgs := formatRows(i.fields, i.rows, false, starter+len(args), sc)
+		query += " VALUES " + values
+		args = append(args, valuesArgs...)
+	}
+
+	if i.conflict != nil {
+		conflict, conflictArgs := i.conflict.format(starter+len(args), sc)
+		query += conflict
+		args = append(args, conflictArgs...)
+	}
+
+	returned, returnedArgs := i.returning.format(starter+len(args), sc)
+	query += returned
+	args = append(args, returnedArgs...)
+
+	return query, args
+}
+
+// formatRows formats every row, each value with the type of its column. With cast the
+// placeholders get cast to it too, for the lists PostgreSQL can not infer the types of
+func formatRows(fields Fields, rows [][]interface{}, cast bool, starter int, sc *scope) (string, []interface{}) {
+	format := formatValue
+	if cast {
+		format = formatCastValue
+	}
+
+	var args []interface{}
+	formatted := make([]string, len(rows))
+	for r, row := range rows {
+		if len(row) != len(fields) {
+			sc.fail(ErrValuesCount)
+			return "", nil
+		}
+
+		values := make([]string, len(row))
+		for v, value := range row {
+			valueFormatted, valueArgs := format(value, fields[v].Type(), starter+len(args), sc)
+			values[v] = valueFormatted
+			args = append(args, valueArgs...)
+		}
+
+		formatted[r] = "(" + strings.Join(values, ", ") + ")"
+	}
+
+	return strings.Join(formatted, ", "), args
+}
@@ -0,0 +1,251 @@
+package querybuilder_test
+
+import (
+	"testing"
+
+	"github.com/gonzispina/querybuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertQuery(test *testing.T) {
+	payments := querybuilder.NewTable("payments")
+
+	test.Run("Insert single row", func(t *testing.T) {
+		query, args, err := querybuilder.InsertInto(payments).
+			Columns(id, amount, dueDate).
+			Values("1234", 10, "2020-01-01").
+			Done()
+
+		expected := "INSERT INTO payments (id, amount, due_date) VALUES ($1, $2, to_timestamp($3));"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", 10, "2020-01-01"}, args)
+	})
+
+	test.Run("Insert multiple rows", func(t *testing.T) {
+		query, args, err := querybuilder.InsertInto(payments).
+			Columns(id, dueDate).
+			Values("1234", "2020-01-01").
+			Values("5678", querybuilder.Expr("now()")).
+			Done()
+
+		expected := "INSERT INTO payments (id, due_date) VALUES ($1, to_timestamp($2)), ($3, now());"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", "2020-01-01", "5678"}, args)
+	})
+
+	test.Run("Insert with a different number of values", func(t *testing.T) {
+		_, _, err := querybuilder.InsertInto(payments).
+			Columns(id, dueDate).
+			Values("1234").
+			Done()
+
+		assert.Equal(t, querybuilder.ErrValuesCount, err)
+	})
+
+	test.Run("Insert without values", func(t *testing.T) {
+		_, _, err := querybuilder.InsertInto(payments).Columns(id).Done()
+		assert.Equal(t, querybuilder.ErrMissingValues, err)
+	})
+
+	test.Run("Insert without table", func(t *testing.T) {
+		_, _, err := querybuilder.InsertInto(querybuilder.Table{}).Columns(id).Values("1").Done()
+		assert.Equal(t, querybuilder.ErrMissingTable, err)
+	})
+
+	test.Run("Insert from select", func(t *testing.T) {
+		active := querybuilder.Select(id, amount).
+			From("payments").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true))
+
+		query, args, err := querybuilder.With("old", querybuilder.Select(id).From("payments").Where(*querybuilder.New().Field(amount).LesserThan(5))).
+			InsertInto(querybuilder.NewTable("archive")).
+			Columns(id, amount).
+			FromSelect(active).
+			Done()
+
+		expected := "WITH old AS (SELECT id FROM payments WHERE (amount < $1))" +
+			" INSERT INTO archive (id, amount) SELECT id, amount FROM payments WHERE (is_active = $2);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{5, true}, args)
+	})
+
+	test.Run("Insert from select with a different number of columns", func(t *testing.T) {
+		_, _, err := querybuilder.InsertInto(payments).
+			Columns(id, amount).
+			FromSelect(querybuilder.Select(id).From("archive")).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrValuesCount, err)
+	})
+
+	test.Run("Insert default values", func(t *testing.T) {
+		query, args, err := querybuilder.InsertInto(payments).DefaultValues().Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO payments DEFAULT VALUES;", query)
+		assert.Empty(t, args)
+	})
+
+	test.Run("Insert default values with columns, rows or a select", func(t *testing.T) {
+		inserts := []interface {
+			Done() (string, []interface{}, error)
+		}{
+			querybuilder.InsertInto(payments).Columns(id, amount).DefaultValues(),
+			querybuilder.InsertInto(payments).Values("1234").DefaultValues(),
+			querybuilder.InsertInto(payments).FromSelect(querybuilder.Select().From("archive")).DefaultValues(),
+		}
+
+		for _, insert := range inserts {
+			_, _, err := insert.Done()
+			assert.Equal(t, querybuilder.ErrDefaultValues, err)
+		}
+	})
+
+	test.Run("Insert with default markers", func(t *testing.T) {
+		query, args, err := querybuilder.InsertInto(payments).
+			Columns(id, amount, dueDate).
+			Values(querybuilder.Default(), 10, querybuilder.Default()).
+			Values("1234", querybuilder.Default(), "2020-01-01").
+			Done()
+
+		expected := "INSERT INTO payments (id, amount, due_date) VALUES (DEFAULT, $1, DEFAULT), ($2, DEFAULT, to_timestamp($3));"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{10, "1234", "2020-01-01"}, args)
+	})
+
+	test.Run("Insert on conflict do nothing", func(t *testing.T) {
+		query, args, err := querybuilder.InsertInto(payments).
+			Columns(id, amount).
+			Values("1234", 10).
+			OnConflict().DoNothing().
+			Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO payments (id, amount) VALUES ($1, $2) ON CONFLICT DO NOTHING;", query)
+		assert.Equal(t, []interface{}{"1234", 10}, args)
+	})
+
+	test.Run("Insert on conflict do update", func(t *testing.T) {
+		query, args, err := querybuilder.InsertInto(payments).
+			Columns(id, amount, dueDate).
+			Values("1234", 10, "2020-01-01").
+			OnConflict(id).DoUpdate().
+			Set(amount, querybuilder.Excluded(amount)).
+			Set(dueDate, "2021-01-01").
+			Set(isActive, querybuilder.Expr("NOT payments.is_active")).
+			Set(userID, userID).
+			Where(*querybuilder.New().Field(amount).LesserThan(querybuilder.Excluded(amount))).
+			Done()
+
+		expected := "INSERT INTO payments (id, amount, due_date) VALUES ($1, $2, to_timestamp($3))" +
+			" ON CONFLICT (id) DO UPDATE SET amount = EXCLUDED.amount, due_date = to_timestamp($4)," +
+			" is_active = NOT payments.is_active, user_id = payments.user_id" +
+			" WHERE (payments.amount < EXCLUDED.amount);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", 10, "2020-01-01", "2021-01-01"}, args)
+	})
+
+	test.Run("Insert on conflict on constraint", func(t *testing.T) {
+		query, _, err := querybuilder.InsertInto(payments).
+			Columns(id).
+			Values("1234").
+			OnConflictOnConstraint("payments_pkey").DoUpdate().
+			Set(amount, 0).
+			Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO payments (id) VALUES ($1) ON CONFLICT ON CONSTRAINT payments_pkey DO UPDATE SET amount = $2;", query)
+	})
+
+	test.Run("Insert on conflict do update without target", func(t *testing.T) {
+		_, _, err := querybuilder.InsertInto(payments).
+			Columns(id).
+			Values("1234").
+			OnConflict().DoUpdate().
+			Set(amount, 0).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrConflictTarget, err)
+	})
+
+	test.Run("Insert returning", func(t *testing.T) {
+		insert := querybuilder.InsertInto(payments).
+			Columns(amount).
+			Values(10).
+			OnConflict(id).DoUpdate().
+			Set(amount, querybuilder.Excluded(amount)).
+			Returning(id, querybuilder.As(dueDate, "due"))
+
+		query, args, err := insert.Done()
+
+		expected := "INSERT INTO payments (amount) VALUES ($1)" +
+			" ON CONFLICT (id) DO UPDATE SET amount = EXCLUDED.amount" +
+			" RETURNING id, due_date AS due;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{10}, args)
+		assert.Equal(t, []string{"id", "due"}, insert.ResultColumns())
+	})
+
+	test.Run("Insert split in chunks", func(t *testing.T) {
+		insert := querybuilder.InsertInto(payments).Columns(id, amount, dueDate)
+		for i := 0; i < 50000; i++ {
+			insert.Values("1234", i, "2020-01-01")
+		}
+
+		statements, err := insert.OnConflict(id).DoUpdate().Set(amount, 0).Chunks()
+
+		// One parameter goes to the conflict update, leaving 65534 / 3 rows per chunk
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(statements))
+		assert.Equal(t, 21844*3+1, len(statements[0].Args))
+		assert.Equal(t, 21844*3+1, len(statements[1].Args))
+		assert.Equal(t, (50000-2*21844)*3+1, len(statements[2].Args))
+		assert.Equal(t, 2*21844, statements[2].Args[1])
+		assert.Contains(t, statements[2].Query, "VALUES ($1, $2, to_timestamp($3)), ")
+		assert.Contains(t, statements[2].Query, " ON CONFLICT (id) DO UPDATE SET amount = $18937;")
+	})
+
+	test.Run("Insert split in chunks by the arguments of each row", func(t *testing.T) {
+		insert := querybuilder.InsertInto(payments).Columns(id, amount)
+		for i := 0; i < 30000; i++ {
+			insert.Values("1234", querybuilder.Expr("$1 + $2", i, 1))
+		}
+
+		statements, err := insert.Chunks()
+
+		// Each row binds 3 parameters, leaving 65535 / 3 rows per chunk
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(statements))
+		assert.Equal(t, 21845*3, len(statements[0].Args))
+		assert.Equal(t, (30000-21845)*3, len(statements[1].Args))
+		assert.Equal(t, 21845, statements[1].Args[1])
+		assert.Contains(t, statements[1].Query, "VALUES ($1, $2 + $3), ($4, $5 + $6), ")
+	})
+
+	test.Run("Insert split in chunks with a row over the limit", func(t *testing.T) {
+		values := make([]interface{}, querybuilder.MaxParameters+1)
+		_, err := querybuilder.InsertInto(payments).
+			Columns(id).
+			Values(querybuilder.Expr("ARRAY[$1, ...]", values...)).
+			Chunks()
+
+		assert.Equal(t, querybuilder.ErrTooManyParameters, err)
+	})
+
+	test.Run("Insert split in a single chunk", func(t *testing.T) {
+		statements, err := querybuilder.InsertInto(payments).Columns(id).Values("1234").Values("5678").Chunks()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []querybuilder.Statement{{
+			Query: "INSERT INTO payments (id) VALUES ($1), ($2);",
+			Args:  []interface{}{"1234", "5678"},
+		}}, statements)
+	})
+}
@@ -1,14 +1,33 @@
 package querybuilder
 
+import "strings"
+
 type joins []*join
 
 type joinType string
 
 const (
-	right joinType = "RIGHT"
-	left  joinType = "LEFT"
+	right   joinType = "RIGHT"
+	left    joinType = "LEFT"
+	inner   joinType = "INNER"
+	full    joinType = "FULL"
+	cross   joinType = "CROSS"
+	natural joinType = "NATURAL"
 )
 
+// format returns every join formatted numbering the placeholders from starter
+func (j joins) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	var str string
+	for _, join := range j {
+		formatted, joinArgs := join.format(starter+len(args), sc)
+		str += formatted
+		args = append(args, joinArgs...)
+	}
+
+	return str, args
+}
+
 type joinTable struct {
 	*Table
 	father *join
@@ -19,28 +38,68 @@ func (j *joinTable) As(as string) *join {
 	return j.father
 }
 
+// On sets the join conditions for a non aliased table
+func (j *joinTable) On(filters Filters) *sel {
+	return j.father.On(filters)
+}
+
+// Using sets the join columns for a non aliased table
+func (j *joinTable) Using(fields ...Field) *sel {
+	return j.father.Using(fields...)
+}
+
 type join struct {
 	table   *joinTable
-	filters Filters
+	filters *Filters
+	using   Fields
 	t       joinType
 	father  *sel
 }
 
-func (j *join) format() string {
-	if j.table.name == "" {
-		return ""
+func (j *join) format(starter int, sc *scope) (string, []interface{}) {
+	if j.table.empty() {
+		return "", nil
 	}
 
-	str := string(j.t) + " " + j.table.name
-	if j.table.as != "" {
-		str += " " + j.table.as
+	table, args := j.table.format(starter, sc)
+	str := " " + string(j.t) + " JOIN " + table
+	if j.t == cross || j.t == natural {
+		return str, args
 	}
 
-	str += " ON (" +  ")"
-	return str
+	if len(j.using) > 0 {
+		names := make([]string, len(j.using))
+		for i, f := range j.using {
+			names[i] = f.Name()
+		}
+
+		return str + " USING (" + strings.Join(names, ", ") + ")", args
+	}
+
+	if j.filters == nil {
+		sc.fail(ErrMissingJoinCondition)
+		return str, args
+	}
+
+	on, onArgs := j.filters.format(starter+len(args), sc)
+	if on == "" {
+		sc.fail(ErrMissingJoinCondition)
+		return str, args
+	}
+
+	return str + " ON " + on, append(args, onArgs...)
 }
 
+// On sets the conditions the joined rows must satisfy
 func (j *join) On(filters Filters) *sel {
-	j.filters = filters
+	j.filters = &filters
+	j.using = nil
+	return j.father
+}
+
+// Using joins the tables on the equality of the given columns
+func (j *join) Using(fields ...Field) *sel {
+	j.using = fields
+	j.filters = nil
 	return j.father
 }
@@ -0,0 +1,74 @@
+package querybuilder
+
+import "strings"
+
+type lockStrength string
+
+const (
+	forUpdate      lockStrength = "FOR UPDATE"
+	forNoKeyUpdate lockStrength = "FOR NO KEY UPDATE"
+	forShare       lockStrength = "FOR SHARE"
+	forKeyShare    lockStrength = "FOR KEY SHARE"
+)
+
+type lockWait string
+
+const (
+	noWait     lockWait = "NOWAIT"
+	skipLocked lockWait = "SKIP LOCKED"
+)
+
+type locks []*lock
+
+func (l locks) format() string {
+	var str string
+	for _, lock := range l {
+		str += lock.format()
+	}
+
+	return str
+}
+
+type lock struct {
+	strength lockStrength
+	tables   []string
+	wait     lockWait
+	father   *sel
+}
+
+func (l *lock) format() string {
+	str := " " + string(l.strength)
+	if len(l.tables) > 0 {
+		str += " OF " + strings.Join(l.tables, ", ")
+	}
+
+	if l.wait != "" {
+		str += " " + string(l.wait)
+	}
+
+	return str
+}
+
+// Of locks only the rows of the given tables or aliases
+func (l *lock) Of(tables ...string) *lock {
+	l.tables = append(l.tables, tables...)
+	return l
+}
+
+// Wait waits for the rows locked by others to be released
+func (l *lock) Wait() *sel {
+	l.wait = ""
+	return l.father
+}
+
+// NoWait fails instead of waiting for the rows locked by others
+func (l *lock) NoWait() *sel {
+	l.wait = noWait
+	return l.father
+}
+
+// SkipLocked leaves out the rows locked by others
+func (l *lock) SkipLocked() *sel {
+	l.wait = skipLocked
+	return l.father
+}
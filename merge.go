@@ -0,0 +1,240 @@
+package querybuilder
+
+import "strings"
+
+type mergeAction string
+
+const (
+	updateAction    mergeAction = "UPDATE"
+	deleteAction    mergeAction = "DELETE"
+	insertAction    mergeAction = "INSERT"
+	doNothingAction mergeAction = "DO NOTHING"
+)
+
+type merge struct {
+	baseQuery
+	source Table
+	on     *Filters
+	whens  []*mergeWhen
+}
+
+// Using sets the rows to merge, either a table name, a Table, a Subquery or Values
+func (m *merge) Using(source interface{}) *merge {
+	m.source = toTable(source)
+	return m
+}
+
+// On sets the conditions matching the source rows to the target ones
+func (m *merge) On(filters Filters) *merge {
+	m.on = &filters
+	return m
+}
+
+// WhenMatched adds an action for the source rows matching a target one, only for the
+// ones also satisfying the filters if given
+func (m *merge) WhenMatched(filters ...Filters) *mergeMatched {
+	return &mergeMatched{when: m.when(true, filters), father: m}
+}
+
+// WhenNotMatched adds an action for the source rows not matching any target one, only for
+// the ones also satisfying the filters if given
+func (m *merge) WhenNotMatched(filters ...Filters) *mergeNotMatched {
+	return &mergeNotMatched{when: m.when(false, filters), father: m}
+}
+
+func (m *merge) when(matched bool, filters []Filters) *mergeWhen {
+	w := &mergeWhen{matched: matched}
+	if len(filters) > 0 {
+		w.condition = &filters[0]
+	}
+
+	m.whens = append(m.whens, w)
+	return w
+}
+
+// Done returns the query and the arguments to be bound to its placeholders
+func (m *merge) Done() (string, []interface{}, error) {
+	return done(m)
+}
+
+// format builds the query numbering its placeholders from starter
+func (m *merge) format(starter int, parent *scope) (string, []interface{}) {
+	if !m.hasTable() {
+		parent.fail(ErrMissingTable)
+		return "", nil
+	}
+
+	if m.source.empty() || m.on == nil || len(m.whens) == 0 {
+		parent.fail(ErrIncompleteMerge)
+		return "", nil
+	}
+
+	sc := newScope(parent, m.table, m.source)
+	query, args := m.with.format(starter, sc)
+	target, targetArgs := m.table.format(starter+len(args), sc)
+	query += "MERGE INTO " + target
+	args = append(args, targetArgs...)
+
+	source, sourceArgs := m.source.format(starter+len(args), sc)
+	query += " USING " + source
+	args = append(args, sourceArgs...)
+
+	on, onArgs := m.on.format(starter+len(args), sc)
+	if on == "" {
+		sc.fail(ErrIncompleteMerge)
+	}
+
+	query += " ON " + on
+	args = append(args, onArgs...)
+
+	for _, w := range m.whens {
+		when, whenArgs := w.format(starter+len(args), sc)
+		query += when
+		args = append(args, whenArgs...)
+	}
+
+	return query, args
+}
+
+// mergeWhen a WHEN clause of a merge
+type mergeWhen struct {
+	matched   bool
+	condition *Filters
+	action    mergeAction
+	sets      assignments
+	fields    Fields
+	values    []interface{}
+}
+
+func (w *mergeWhen) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	str := " WHEN NOT MATCHED"
+	if w.matched {
+		str = " WHEN MATCHED"
+	}
+
+	if w.condition != nil {
+		condition, conditionArgs := w.condition.format(starter, sc)
+		if condition != "" {
+			str += " AND " + condition
+			args = append(args, conditionArgs...)
+		}
+	}
+
+	if w.action == "" {
+		w.action = doNothingAction
+	}
+
+	str += " THEN " + string(w.action)
+	switch w.action {
+	case updateAction:
+		if len(w.sets) == 0 {
+			sc.fail(ErrMissingSet)
+		}
+
+		sets, setArgs := w.sets.format(starter+len(args), sc)
+		str += " SET " + sets
+		args = append(args, setArgs...)
+	case insertAction:
+		if w.values == nil {
+			if len(w.fields) > 0 {
+				sc.fail(ErrDefaultValues)
+			}
+
+			return str + " DEFAULT VALUES", args
+		}
+
+		if len(w.values) == 0 {
+			sc.fail(ErrMissingValues)
+		}
+
+		if len(w.fields) > 0 {
+			str += " (" + strings.Join(w.fields.Names(), ", ") + ")"
+		}
+
+		values, valuesArgs := formatRows(w.fields, [][]interface{}{w.values}, false, starter+len(args), sc)
+		str += " VALUES " + values
+		args = append(args, valuesArgs...)
+	}
+
+	return str, args
+}
+
+// mergeMatched the action for the matched rows
+type mergeMatched struct {
+	when   *mergeWhen
+	father *merge
+}
+
+// Update updates the matched target rows
+func (w *mergeMatched) Update() *mergeUpdate {
+	w.when.action = updateAction
+	return &mergeUpdate{merge: w.father, when: w.when}
+}
+
+// Delete deletes the matched target rows
+func (w *mergeMatched) Delete() *merge {
+	w.when.action = deleteAction
+	return w.father
+}
+
+// DoNothing leaves the matched target rows as they are
+func (w *mergeMatched) DoNothing() *merge {
+	w.when.action = doNothingAction
+	return w.father
+}
+
+// mergeUpdate the assignments of a WHEN MATCHED THEN UPDATE, the merge can be carried on from it
+type mergeUpdate struct {
+	*merge
+	when *mergeWhen
+}
+
+// Set sets field to value on the matched rows. Value can be a parameter, a Field of
+// either table or an expression
+func (u *mergeUpdate) Set(field Field, value interface{}) *mergeUpdate {
+	u.when.sets = append(u.when.sets, &assignment{field: field, value: value})
+	return u
+}
+
+// mergeNotMatched the action for the source rows not matched
+type mergeNotMatched struct {
+	when   *mergeWhen
+	father *merge
+}
+
+// Insert inserts a row in the given columns for each source row not matched
+func (w *mergeNotMatched) Insert(fields ...Field) *mergeInsert {
+	w.when.action = insertAction
+	w.when.fields = fields
+	return &mergeInsert{when: w.when, father: w.father}
+}
+
+// DoNothing skips the source rows not matched
+func (w *mergeNotMatched) DoNothing() *merge {
+	w.when.action = doNothingAction
+	return w.father
+}
+
+// mergeInsert the values of a WHEN NOT MATCHED THEN INSERT
+type mergeInsert struct {
+	when   *mergeWhen
+	father *merge
+}
+
+// Values sets the values inserted, one for each column. They can be parameters, Fields
+// of the source or expressions
+func (i *mergeInsert) Values(values ...interface{}) *merge {
+	if values == nil {
+		values = []interface{}{}
+	}
+
+	i.when.values = values
+	return i.father
+}
+
+// DefaultValues inserts rows made of the default value of every column
+func (i *mergeInsert) DefaultValues() *merge {
+	i.when.values = nil
+	return i.father
+}
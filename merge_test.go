@@ -0,0 +1,110 @@
+package querybuilder_test
+
+import (
+	"testing"
+
+	"github.com/gonzispina/querybuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeQuery(test *testing.T) {
+	payments := querybuilder.NewTable("payments")
+
+	test.Run("Merge with every action", func(t *testing.T) {
+		staging := querybuilder.NewTable("payments_staging").As("s")
+		source := func(f querybuilder.Field) querybuilder.Field { return querybuilder.Qualify(f, "s") }
+
+		query, args, err := querybuilder.MergeInto(payments).
+			Using(staging).
+			On(*querybuilder.New().Field(id).EqualTo(source(id))).
+			WhenMatched(*querybuilder.New().Field(source(isActive)).EqualTo(false)).Delete().
+			WhenMatched().Update().
+			Set(amount, source(amount)).
+			Set(dueDate, "2020-01-01").
+			WhenNotMatched(*querybuilder.New().Field(source(amount)).GreaterThan(0)).Insert(id, amount, dueDate).
+			Values(source(id), source(amount), "2021-01-01").
+			WhenNotMatched().DoNothing().
+			Done()
+
+		expected := "MERGE INTO payments USING payments_staging AS s ON (payments.id = s.id)" +
+			" WHEN MATCHED AND (s.is_active = $1) THEN DELETE" +
+			" WHEN MATCHED THEN UPDATE SET amount = s.amount, due_date = to_timestamp($2)" +
+			" WHEN NOT MATCHED AND (s.amount > $3) THEN INSERT (id, amount, due_date) VALUES (s.id, s.amount, to_timestamp($4))" +
+			" WHEN NOT MATCHED THEN DO NOTHING;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{false, "2020-01-01", 0, "2021-01-01"}, args)
+	})
+
+	test.Run("Merge using a subquery", func(t *testing.T) {
+		latest := querybuilder.Select(id, amount).
+			From("payments_staging").
+			Where(*querybuilder.New().Field(dueDate).GreaterThan("2020-01-01"))
+
+		query, args, err := querybuilder.MergeInto(payments).
+			Using(querybuilder.Subquery(latest).As("l")).
+			On(*querybuilder.New().Field(id).EqualTo(querybuilder.Qualify(id, "l"))).
+			WhenMatched().DoNothing().
+			WhenNotMatched().Insert().DefaultValues().
+			Done()
+
+		expected := "MERGE INTO payments" +
+			" USING (SELECT id, amount FROM payments_staging WHERE (due_date > to_timestamp($1))) AS l" +
+			" ON (payments.id = l.id)" +
+			" WHEN MATCHED THEN DO NOTHING" +
+			" WHEN NOT MATCHED THEN INSERT DEFAULT VALUES;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"2020-01-01"}, args)
+	})
+
+	test.Run("Merge without when clauses", func(t *testing.T) {
+		_, _, err := querybuilder.MergeInto(payments).
+			Using("payments_staging").
+			On(*querybuilder.New().Field(id).EqualTo(id)).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrIncompleteMerge, err)
+	})
+
+	test.Run("Merge with empty ON conditions", func(t *testing.T) {
+		_, _, err := querybuilder.MergeInto(payments).
+			Using("payments_staging").
+			On(*querybuilder.New()).
+			WhenMatched().Delete().
+			Done()
+
+		assert.Equal(t, querybuilder.ErrIncompleteMerge, err)
+	})
+
+	test.Run("Merge insert default values with columns", func(t *testing.T) {
+		_, _, err := querybuilder.MergeInto(payments).
+			Using("payments_staging").
+			On(*querybuilder.New().Field(id).EqualTo(id)).
+			WhenNotMatched().Insert(id, amount).DefaultValues().
+			Done()
+
+		assert.Equal(t, querybuilder.ErrDefaultValues, err)
+	})
+
+	test.Run("Merge insert without values", func(t *testing.T) {
+		_, _, err := querybuilder.MergeInto(payments).
+			Using("payments_staging").
+			On(*querybuilder.New().Field(id).EqualTo(id)).
+			WhenNotMatched().Insert().Values().
+			Done()
+
+		assert.Equal(t, querybuilder.ErrMissingValues, err)
+	})
+
+	test.Run("Merge insert with a different number of values", func(t *testing.T) {
+		_, _, err := querybuilder.MergeInto(payments).
+			Using("payments_staging").
+			On(*querybuilder.New().Field(id).EqualTo(id)).
+			WhenNotMatched().Insert(id, amount).Values("1234").
+			Done()
+
+		assert.Equal(t, querybuilder.ErrValuesCount, err)
+	})
+}
@@ -53,6 +53,8 @@ const (
 	lesserEqual relationalType = "<="
 	// In operator
 	in relationalType = "IN"
+	// NotIn operator
+	notIn relationalType = "NOT IN"
 	// Between operator
 	between = "BETWEEN"
 	// IsNull operator
@@ -86,7 +88,7 @@ func(t relationalType) newRelational(fieldType Type, values ...interface{}) rela
 }
 
 type relational interface {
-	format(starter int) (string, []interface{})
+	format(starter int, sc *scope) (string, []interface{})
 }
 
 type baseRelational struct {
@@ -95,52 +97,59 @@ type baseRelational struct {
 	fieldType Type
 }
 
-func (b *baseRelational) format(starter int) (string, []interface{}) {
+// placeholders formats every value numbering the bound ones from starter
+func (b *baseRelational) placeholders(starter int, sc *scope) ([]string, []interface{}) {
+	var args []interface{}
+	formatted := make([]string, len(b.values))
+	for index, value := range b.values {
+		valueFormatted, valueArgs := formatValue(value, b.fieldType, starter+len(args), sc)
+		formatted[index] = valueFormatted
+		args = append(args, valueArgs...)
+	}
+
+	return formatted, args
+}
+
+func (b *baseRelational) format(starter int, sc *scope) (string, []interface{}) {
 	if len(b.values) == 0 {
-		return "", b.values
+		return "", nil
 	}
 
-	dollarValue := fmt.Sprintf("$%v", starter)
-	return fmt.Sprintf("%s %s", b.relation, b.fieldType.format(dollarValue)), b.values
+	formatted, args := b.placeholders(starter, sc)
+	return fmt.Sprintf("%s %s", b.relation, formatted[0]), args
 }
 
 type inRelational struct {
 	*baseRelational
 }
 
-func (i *inRelational) format(starter int) (string, []interface{}) {
+func (i *inRelational) format(starter int, sc *scope) (string, []interface{}) {
 	if len(i.values) == 0 {
-		return "", i.values
-	}
-
-	formatted := make([]string, len(i.values))
-	for index, _ := range i.values {
-		dollarValue := fmt.Sprintf("$%v", starter + index )
-		formatted[index] = i.fieldType.format(dollarValue)
+		return "", nil
 	}
 
-	return "IN (" + strings.Join(formatted, ", ") + ")", i.values
+	formatted, args := i.placeholders(starter, sc)
+	return "IN (" + strings.Join(formatted, ", ") + ")", args
 }
 
 type betweenRelational struct {
 	*baseRelational
 }
 
-func (b *betweenRelational) format(starter int) (string, []interface{}) {
+func (b *betweenRelational) format(starter int, sc *scope) (string, []interface{}) {
 	if len(b.values) < 2 {
-		return "", b.values
+		return "", nil
 	}
 
-	first := b.fieldType.format(fmt.Sprintf("$%v", starter))
-	second := b.fieldType.format(fmt.Sprintf("$%v", starter + 1))
-	return fmt.Sprintf("BETWEEN (%s AND %s)", first, second), b.values
+	formatted, args := b.placeholders(starter, sc)
+	return fmt.Sprintf("BETWEEN (%s AND %s)", formatted[0], formatted[1]), args
 }
 
 type isNullRelational struct {
 	*baseRelational
 }
 
-func (i *isNullRelational) format(starter int) (string, []interface{}) {
+func (i *isNullRelational) format(starter int, sc *scope) (string, []interface{}) {
 	return "IS NULL", i.values
 }
 
@@ -148,7 +157,47 @@ type isNotNullRelational struct {
 	*baseRelational
 }
 
-func (i *isNotNullRelational) format(starter int) (string, []interface{}) {
+func (i *isNotNullRelational) format(starter int, sc *scope) (string, []interface{}) {
 	return "IS NOT NULL", i.values
 }
 
+type subqueryRelational struct {
+	relation relationalType
+	query    *sel
+}
+
+func (s *subqueryRelational) format(starter int, sc *scope) (string, []interface{}) {
+	if s.query == nil {
+		sc.fail(ErrMissingSubquery)
+		return "", nil
+	}
+
+	query, args := s.query.nested(starter, sc)
+	return fmt.Sprintf("%s %s", s.relation, query), args
+}
+
+// Any compares against every row of the query, being true if any comparison is
+func Any(query *sel) *quantified {
+	return &quantified{quantifier: "ANY", query: query}
+}
+
+// All compares against every row of the query, being true if all the comparisons are
+func All(query *sel) *quantified {
+	return &quantified{quantifier: "ALL", query: query}
+}
+
+type quantified struct {
+	quantifier string
+	query      *sel
+}
+
+func (q *quantified) format(starter int, sc *scope) (string, []interface{}) {
+	if q.query == nil {
+		sc.fail(ErrMissingSubquery)
+		return "", nil
+	}
+
+	query, args := q.query.nested(starter, sc)
+	return q.quantifier + " " + query, args
+}
+
@@ -1,5 +1,7 @@
 package querybuilder
 
+import "strings"
+
 type orderType string
 
 const (
@@ -7,22 +9,59 @@ const (
 	desc orderType = "DESC"
 )
 
+type nullsType string
+
+const (
+	nullsFirst nullsType = "NULLS FIRST"
+	nullsLast  nullsType = "NULLS LAST"
+)
+
+type orders []*order
+
+// format returns every sort key formatted numbering the placeholders from starter
+func (o orders) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	var formatted []string
+	for _, order := range o {
+		orderFormatted, orderArgs := order.format(starter+len(args), sc)
+		if orderFormatted == "" {
+			continue
+		}
+
+		formatted = append(formatted, orderFormatted)
+		args = append(args, orderArgs...)
+	}
+
+	if len(formatted) == 0 {
+		return "", nil
+	}
+
+	return " ORDER BY " + strings.Join(formatted, ", "), args
+}
+
 type order struct {
 	t      orderType
+	nulls  nullsType
 	column Field
 	father *sel
 }
 
-func (o *order) format() string {
+func (o *order) format(starter int, sc *scope) (string, []interface{}) {
 	if o.column == nil {
-		return ""
+		return "", nil
 	}
 
 	if o.t == "" {
 		o.t = asc
 	}
 
-	return " ORDER BY " + o.column.Name() + " " + string(o.t)
+	column, args := formatField(o.column, starter, sc)
+	str := column + " " + string(o.t)
+	if o.nulls != "" {
+		str += " " + string(o.nulls)
+	}
+
+	return str, args
 }
 
 func (o *order) Asc() *sel {
@@ -34,3 +73,15 @@ func (o *order) Desc() *sel {
 	o.t = desc
 	return o.father
 }
+
+// NullsFirst sorts the null values before the rest
+func (o *order) NullsFirst() *order {
+	o.nulls = nullsFirst
+	return o
+}
+
+// NullsLast sorts the null values after the rest
+func (o *order) NullsLast() *order {
+	o.nulls = nullsLast
+	return o
+}
@@ -1,45 +1,66 @@
 package querybuilder
 
 type Query interface {
-	Done() (string, []interface{})
+	Done() (string, []interface{}, error)
 	hasTable() bool
+	format(starter int, sc *scope) (string, []interface{})
 }
 
 type baseQuery struct {
 	table Table
+	with  *with
 }
 
 func (b baseQuery) hasTable() bool {
-	return b.table.name != ""
+	return !b.table.empty()
 }
 
-type insert struct {
-	baseQuery
-	fields Fields
-	values []string
-}
+// done formats q numbering its placeholders from 1, failing with the first error found
+func done(q Query) (string, []interface{}, error) {
+	if !q.hasTable() {
+		return "", nil, ErrMissingTable
+	}
 
-type update struct {
-	baseQuery
-	fields  Fields
-	values  []string
-	filters Filters
-}
+	sc := newScope(nil)
+	query, args := q.format(1, sc)
+	if err := sc.error(); err != nil {
+		return "", nil, err
+	}
 
-type delete struct {
-	baseQuery
-	filters Filters
+	return query + ";", args, nil
 }
 
 // Select initiates a new select query
 func Select(fields ...Field) *sel {
-	s := &sel{}
+	s := &sel{limit: &limit{}}
 	s.fields = fields
 	return s
 }
 
-func InsertInto(table Table) insert {
-	return insert{
+// InsertInto initiates a new insert query
+func InsertInto(table Table) *insert {
+	return &insert{
+		baseQuery: baseQuery{table: table},
+	}
+}
+
+// Update initiates a new update query
+func Update(table Table) *update {
+	return &update{
 		baseQuery: baseQuery{table: table},
 	}
 }
+
+// DeleteFrom initiates a new delete query
+func DeleteFrom(table Table) *delete {
+	return &delete{
+		baseQuery: baseQuery{table: table},
+	}
+}
+
+// MergeInto initiates a new merge query into target
+func MergeInto(target Table) *merge {
+	return &merge{
+		baseQuery: baseQuery{table: target},
+	}
+}
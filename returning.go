@@ -0,0 +1,23 @@
+package querybuilder
+
+// returning the fields a statement gives back from the rows it changed
+type returning Fields
+
+func (r *returning) add(fields ...Field) {
+	*r = append(*r, fields...)
+}
+
+// ResultColumns retrieves the name of each returned column in order, the alias
+// for the aliased fields, so the rows can be mapped when scanned
+func (r returning) ResultColumns() []string {
+	return Fields(r).Names()
+}
+
+func (r returning) format(starter int, sc *scope) (string, []interface{}) {
+	if len(r) == 0 {
+		return "", nil
+	}
+
+	fields, args := Fields(r).selection(starter, sc)
+	return " RETURNING " + fields, args
+}
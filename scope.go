@@ -0,0 +1,114 @@
+package querybuilder
+
+import "strings"
+
+// scope holds the tables a query can reference while it gets formatted. Nested queries
+// get their own scope pointing to the one of the query they are part of, sharing with
+// it the first error found
+type scope struct {
+	tables []Table
+	parent *scope
+	err    *error
+}
+
+func newScope(parent *scope, tables ...Table) *scope {
+	err := new(error)
+	if parent != nil {
+		err = parent.err
+	}
+
+	return &scope{
+		tables: tables,
+		parent: parent,
+		err:    err,
+	}
+}
+
+// detached creates a scope for a query that can not reference the current tables,
+// such as a derived table
+func (sc *scope) detached() *scope {
+	detached := newScope(nil)
+	if sc != nil {
+		detached.err = sc.err
+	}
+
+	return detached
+}
+
+// fail keeps err unless another one was found before
+func (sc *scope) fail(err error) {
+	if sc == nil || *sc.err != nil {
+		return
+	}
+
+	*sc.err = err
+}
+
+// error retrieves the first error found while formatting
+func (sc *scope) error() error {
+	return *sc.err
+}
+
+// qualified tells whether there is more than one table to tell the columns apart from
+func (sc *scope) qualified() bool {
+	count := 0
+	for s := sc; s != nil; s = s.parent {
+		count += len(s.tables)
+	}
+
+	return count > 1
+}
+
+// prefix resolves the name a table is referenced by, its alias when it has one. A table
+// read more than once under different aliases can not be told apart by its name
+func (sc *scope) prefix(table string) string {
+	for s := sc; s != nil; s = s.parent {
+		for _, t := range s.tables {
+			if t.as != "" && t.as == table {
+				return t.as
+			}
+		}
+
+		var aliased []Table
+		for _, t := range s.tables {
+			if !strings.EqualFold(t.name, table) {
+				continue
+			}
+
+			if t.as == "" {
+				return t.prefix()
+			}
+
+			aliased = append(aliased, t)
+		}
+
+		if len(aliased) > 1 {
+			sc.fail(ErrAmbiguousTable)
+		}
+
+		if len(aliased) > 0 {
+			return aliased[0].prefix()
+		}
+	}
+
+	return table
+}
+
+// column formats the field qualifying it only when the scope needs it
+func (sc *scope) column(f Field) string {
+	if f.Table() == "" || !sc.qualified() {
+		return f.Name()
+	}
+
+	return sc.prefix(f.Table()) + "." + f.Name()
+}
+
+// reference formats a field compared against another one. Out of a query there is
+// nothing to resolve it with, so it gets qualified by its own table
+func (sc *scope) reference(f Field) string {
+	if sc == nil {
+		return column(f)
+	}
+
+	return sc.column(f)
+}
@@ -2,15 +2,23 @@ package querybuilder
 
 type sel struct {
 	baseQuery
-	fields Fields
-	joins  joins
-	filter *Filters
-	order  *order
-	limit  *limit
+	distinct   bool
+	distinctOn Fields
+	fields     Fields
+	joins      joins
+	filter     *Filters
+	group      Fields
+	having     *Filters
+	windows    namedWindows
+	compounds  compounds
+	orders     orders
+	limit      *limit
+	locks      locks
 }
 
-func (s *sel) From(table string) *sel {
-	s.table.name = table
+// From sets the table to read from, either a table name, a Table or a Subquery
+func (s *sel) From(table interface{}) *sel {
+	s.table = toTable(table)
 	return s
 }
 
@@ -19,13 +27,11 @@ func (s *sel) As(as string) *sel {
 	return s
 }
 
-func (s *sel) LeftJoin(table string) *joinTable {
-	j := &join{t: left}
+func (s *sel) join(t joinType, table interface{}) *joinTable {
+	source := toTable(table)
+	j := &join{t: t, father: s}
 	j.table = &joinTable{
-		Table: &Table{
-			name:    table,
-			as:      "",
-		},
+		Table:  &source,
 		father: j,
 	}
 
@@ -33,18 +39,36 @@ func (s *sel) LeftJoin(table string) *joinTable {
 	return j.table
 }
 
-func (s *sel) RightJoin(table string) *joinTable {
-	j := &join{t: right}
-	j.table = &joinTable{
-		Table: &Table{
-			name:    table,
-			as:      "",
-		},
-		father: j,
-	}
+// LeftJoin adds a LEFT JOIN against table
+func (s *sel) LeftJoin(table interface{}) *joinTable {
+	return s.join(left, table)
+}
 
-	s.joins = append(s.joins, j)
-	return j.table
+// RightJoin adds a RIGHT JOIN against table
+func (s *sel) RightJoin(table interface{}) *joinTable {
+	return s.join(right, table)
+}
+
+// InnerJoin adds an INNER JOIN against table
+func (s *sel) InnerJoin(table interface{}) *joinTable {
+	return s.join(inner, table)
+}
+
+// FullJoin adds a FULL JOIN against table
+func (s *sel) FullJoin(table interface{}) *joinTable {
+	return s.join(full, table)
+}
+
+// CrossJoin adds a CROSS JOIN against table, it takes no conditions
+func (s *sel) CrossJoin(table interface{}) *sel {
+	s.join(cross, table)
+	return s
+}
+
+// NaturalJoin adds a NATURAL JOIN against table, it takes no conditions
+func (s *sel) NaturalJoin(table interface{}) *sel {
+	s.join(natural, table)
+	return s
 }
 
 func (s *sel) Where(filters Filters) *sel {
@@ -56,13 +80,64 @@ func (s *sel) Where(filters Filters) *sel {
 	return s
 }
 
+// GroupBy groups the rows by the given fields
+func (s *sel) GroupBy(fields ...Field) *sel {
+	s.group = append(s.group, fields...)
+	return s
+}
+
+// Having filters the groups, conditions can be set on aggregate expressions
+func (s *sel) Having(filters Filters) *sel {
+	if !s.hasTable() {
+		return s
+	}
+
+	s.having = &filters
+	return s
+}
+
+// Window names a window so the functions of the query can be computed over it
+func (s *sel) Window(name string, w *window) *sel {
+	s.windows = append(s.windows, &namedWindow{name: name, window: w})
+	return s
+}
+
+// Union adds the rows of query removing the duplicated ones
+func (s *sel) Union(query *sel) *sel {
+	return s.combine(union, query)
+}
+
+// UnionAll adds the rows of query
+func (s *sel) UnionAll(query *sel) *sel {
+	return s.combine(unionAll, query)
+}
+
+// Intersect keeps the rows also returned by query
+func (s *sel) Intersect(query *sel) *sel {
+	return s.combine(intersect, query)
+}
+
+// Except removes the rows returned by query
+func (s *sel) Except(query *sel) *sel {
+	return s.combine(except, query)
+}
+
+func (s *sel) combine(t compoundType, query *sel) *sel {
+	s.compounds = append(s.compounds, &compound{t: t, query: query})
+	return s
+}
+
+// OrderBy adds a sort key, calling it again adds the next one. Expressions and
+// select list aliases can be sorted by through Expr. With set operations it sorts
+// the whole result
 func (s *sel) OrderBy(column Field) *order {
-	s.order = &order{
+	o := &order{
 		column: column,
 		father: s,
 	}
 
-	return s.order
+	s.orders = append(s.orders, o)
+	return o
 }
 
 func (s *sel) Limit(limit int) *sel {
@@ -83,8 +158,71 @@ func (s *sel) Offset(offset int) *sel {
 	return s
 }
 
-func (s *sel) Done() (string, interface{}) {
+// Distinct removes the duplicated rows
+func (s *sel) Distinct() *sel {
+	s.distinct = true
+	return s
+}
+
+// DistinctOn keeps the first row of each group of rows sharing the given fields. The
+// fields must be the leftmost ORDER BY expressions
+func (s *sel) DistinctOn(fields ...Field) *sel {
+	s.distinctOn = append(s.distinctOn, fields...)
+	return s
+}
+
+// ResultColumns retrieves the name of each column of the result in order, the alias
+// for the aliased fields, so the rows can be mapped when scanned
+func (s *sel) ResultColumns() []string {
+	return s.fields.Names()
+}
+
+// ForUpdate locks the selected rows for them to be updated or deleted
+func (s *sel) ForUpdate() *lock {
+	return s.lock(forUpdate)
+}
+
+// ForNoKeyUpdate locks the selected rows for them to be updated without changing their keys
+func (s *sel) ForNoKeyUpdate() *lock {
+	return s.lock(forNoKeyUpdate)
+}
+
+// ForShare locks the selected rows against being updated or deleted
+func (s *sel) ForShare() *lock {
+	return s.lock(forShare)
+}
+
+// ForKeyShare locks the selected rows against their keys being updated or deleted
+func (s *sel) ForKeyShare() *lock {
+	return s.lock(forKeyShare)
+}
+
+func (s *sel) lock(strength lockStrength) *lock {
+	l := &lock{strength: strength, father: s}
+	s.locks = append(s.locks, l)
+	return l
+}
+
+// Done returns the query and the arguments to be bound to its placeholders
+func (s *sel) Done() (string, []interface{}, error) {
+	return done(s)
+}
+
+// tables returns every table the query reads from
+func (s *sel) tables() []Table {
+	tables := []Table{s.table}
+	for _, j := range s.joins {
+		tables = append(tables, *j.table.Table)
+	}
+
+	return tables
+}
+
+// format builds the query numbering its placeholders from starter, parent is the
+// scope of the query this one is nested in, if any
+func (s *sel) format(starter int, parent *scope) (string, []interface{}) {
 	if !s.hasTable() {
+		parent.fail(ErrMissingTable)
 		return "", nil
 	}
 
@@ -92,23 +230,202 @@ func (s *sel) Done() (string, interface{}) {
 		s.fields = Fields{wildcard}
 	}
 
-	query := "SELECT " + s.fields.Format()
-	query += " FROM " + s.table.format()
+	sc := newScope(parent, s.tables()...)
+	if !s.distinctOnOrdered(sc) {
+		sc.fail(ErrDistinctOnOrder)
+	}
+
+	if len(s.locks) > 0 && !s.lockable() {
+		sc.fail(ErrLockNotAllowed)
+	}
 
-	if s.joins != nil && len(s.joins) > 0 {
-		for _, j := range s.joins {
-			query += j.format()
+	if !s.windowsDeclared() {
+		sc.fail(ErrUndeclaredWindow)
+	}
+
+	query, args := s.with.format(starter, sc)
+	query += "SELECT "
+	if len(s.distinctOn) > 0 {
+		distinctOn, distinctArgs := s.distinctOn.format(starter+len(args), sc)
+		query += "DISTINCT ON (" + distinctOn + ") "
+		args = append(args, distinctArgs...)
+	} else if s.distinct {
+		query += "DISTINCT "
+	}
+
+	fields, fieldArgs := s.fields.selection(starter+len(args), sc)
+	query += fields
+	args = append(args, fieldArgs...)
+	from, fromArgs := s.table.format(starter+len(args), sc)
+	query += " FROM " + from
+	args = append(args, fromArgs...)
+
+	joined, joinArgs := s.joins.format(starter+len(args), sc)
+	query += joined
+	args = append(args, joinArgs...)
+
+	if s.filter != nil {
+		where, whereArgs := s.filter.format(starter+len(args), sc)
+		if where != "" {
+			query += " WHERE " + where
+			args = append(args, whereArgs...)
 		}
 	}
 
-	if s.order != nil {
-		query += s.order.format()
+	if len(s.group) > 0 {
+		grouped, groupArgs := s.group.format(starter+len(args), sc)
+		query += " GROUP BY " + grouped
+		args = append(args, groupArgs...)
+	}
+
+	if s.having != nil {
+		having, havingArgs := s.having.format(starter+len(args), sc)
+		if having != "" {
+			query += " HAVING " + having
+			args = append(args, havingArgs...)
+		}
 	}
 
+	windows, windowArgs := s.windows.format(starter+len(args), sc)
+	query += windows
+	args = append(args, windowArgs...)
+
+	// The sorting of a set operation applies to the result, whose columns have no table
+	orderScope := sc
+	if len(s.compounds) > 0 {
+		for _, c := range s.compounds {
+			if !s.sameColumns(c.query) {
+				sc.fail(ErrColumnCount)
+			}
+
+			if len(c.query.locks) > 0 {
+				sc.fail(ErrLockNotAllowed)
+			}
+		}
+
+		combined, combinedArgs := s.compounds.format(starter+len(args), sc)
+		query += combined
+		args = append(args, combinedArgs...)
+		orderScope = sc.detached()
+	}
+
+	ordered, orderArgs := s.orders.format(starter+len(args), orderScope)
+	query += ordered
+	args = append(args, orderArgs...)
+
 	if s.limit != nil {
 		query += s.limit.format()
 	}
 
-	query += ";"
-	return query, nil
+	query += s.locks.format()
+
+	return query, args
+}
+
+// nested formats the query between parentheses to be part of another one
+func (s *sel) nested(starter int, parent *scope) (string, []interface{}) {
+	query, args := s.format(starter, parent)
+	return "(" + query + ")", args
+}
+
+// distinctOnOrdered checks the DISTINCT ON expressions are the leftmost ORDER BY ones
+func (s *sel) distinctOnOrdered(sc *scope) bool {
+	if len(s.distinctOn) == 0 || len(s.orders) == 0 {
+		return true
+	}
+
+	if len(s.orders) < len(s.distinctOn) {
+		return false
+	}
+
+	leftmost := map[string]bool{}
+	for _, o := range s.orders[:len(s.distinctOn)] {
+		formatted, _ := formatField(o.column, 1, sc)
+		leftmost[formatted] = true
+	}
+
+	for _, f := range s.distinctOn {
+		formatted, _ := formatField(f, 1, sc)
+		if !leftmost[formatted] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// windowsDeclared checks every window referenced by name is defined in the WINDOW clause
+func (s *sel) windowsDeclared() bool {
+	declared := map[string]bool{}
+	for _, w := range s.windows {
+		declared[w.name] = true
+	}
+
+	fields := append(Fields{}, s.fields...)
+	for _, o := range s.orders {
+		fields = append(fields, o.column)
+	}
+
+	for _, f := range fields {
+		if a, ok := f.(Aliased); ok {
+			f = a.Field()
+		}
+
+		if w, ok := f.(*windowed); ok && w.window == nil && !declared[w.name] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameColumns checks both queries return the same number of columns, when it can be told
+func (s *sel) sameColumns(query *sel) bool {
+	if query.hasWildcard() || s.hasWildcard() {
+		return true
+	}
+
+	return len(s.fields) == len(query.fields)
+}
+
+func (s *sel) hasWildcard() bool {
+	if len(s.fields) == 0 {
+		return true
+	}
+
+	for _, f := range s.fields {
+		if f == wildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockable tells whether every row of the result maps to a single row of the tables
+func (s *sel) lockable() bool {
+	if s.distinct || len(s.distinctOn) > 0 || len(s.group) > 0 || s.having != nil {
+		return false
+	}
+
+	if len(s.windows) > 0 || len(s.compounds) > 0 {
+		return false
+	}
+
+	for _, f := range s.fields {
+		if a, ok := f.(Aliased); ok {
+			f = a.Field()
+		}
+
+		switch f.(type) {
+		case *aggregate, *windowed:
+			return false
+		}
+
+		if f == count {
+			return false
+		}
+	}
+
+	return true
 }
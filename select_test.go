@@ -10,19 +10,19 @@ import (
 
 func TestSelectQuery(test *testing.T) {
 	test.Run("Select with columns", func(t *testing.T) {
-		query, _ := querybuilder.Select(dueDate, userID).From("results").Done()
+		query, _, _ := querybuilder.Select(dueDate, userID).From("results").Done()
 		expected := "SELECT due_date, user_id FROM results;"
 		assert.Equal(t, expected, query)
 	})
 
 	test.Run("Select without columns", func(t *testing.T) {
-		query, _ := querybuilder.Select().From("results").Done()
+		query, _, _ := querybuilder.Select().From("results").Done()
 		expected := "SELECT * FROM results;"
 		assert.Equal(t, expected, query)
 	})
 
 	test.Run("Select with columns and order asc", func(t *testing.T) {
-		query, _ := querybuilder.Select().
+		query, _, _ := querybuilder.Select().
 			From("results").
 			OrderBy(dueDate).Asc().
 			Done()
@@ -32,7 +32,7 @@ func TestSelectQuery(test *testing.T) {
 	})
 
 	test.Run("Select with columns and order desc", func(t *testing.T) {
-		query, _ := querybuilder.Select().
+		query, _, _ := querybuilder.Select().
 			From("results").
 			OrderBy(dueDate).Desc().
 			Done()
@@ -42,7 +42,7 @@ func TestSelectQuery(test *testing.T) {
 	})
 
 	test.Run("Select without columns and no filters and with limit and offset", func(t *testing.T) {
-		query, _ := querybuilder.Select().
+		query, _, _ := querybuilder.Select().
 			From("results").
 			Limit(2).
 			Offset(5).
@@ -53,7 +53,7 @@ func TestSelectQuery(test *testing.T) {
 	})
 
 	test.Run("TestSelectQuery - Select join", func(t *testing.T) {
-		query, _ := querybuilder.Select().
+		query, _, _ := querybuilder.Select().
 			From("results").
 			Limit(2).
 			Offset(5).
@@ -62,4 +62,542 @@ func TestSelectQuery(test *testing.T) {
 		expected := "SELECT * FROM results LIMIT 2 OFFSET 5;"
 		assert.Equal(t, expected, query)
 	})
+
+	test.Run("Select with where", func(t *testing.T) {
+		query, args, _ := querybuilder.Select().
+			From("results").
+			Where(*querybuilder.New().
+				Field(userID).EqualTo("1234").
+				And().
+				Field(amount).Between(1, 2)).
+			Limit(2).
+			Done()
+
+		expected := "SELECT * FROM results WHERE (user_id = $1 AND amount BETWEEN ($2 AND $3)) LIMIT 2;"
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", 1, 2}, args)
+	})
+
+	test.Run("Select without filters returns no args", func(t *testing.T) {
+		query, args, _ := querybuilder.Select().
+			From("results").
+			Where(*querybuilder.New()).
+			Done()
+
+		assert.Equal(t, "SELECT * FROM results;", query)
+		assert.Empty(t, args)
+	})
+
+	test.Run("Select join numbers placeholders before where", func(t *testing.T) {
+		query, args, _ := querybuilder.Select().
+			From("payments").
+			LeftJoin("users").As("u").On(*querybuilder.New().Field(isActive).EqualTo(true)).
+			InnerJoin("accounts").On(*querybuilder.New().Field(amount).GreaterThan(10)).
+			Where(*querybuilder.New().Field(userID).EqualTo("1234")).
+			Done()
+
+		expected := "SELECT * FROM payments" +
+			" LEFT JOIN users AS u ON (payments.is_active = $1)" +
+			" INNER JOIN accounts ON (payments.amount > $2)" +
+			" WHERE (payments.user_id = $3);"
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true, 10, "1234"}, args)
+	})
+
+	test.Run("Select join using, cross and natural", func(t *testing.T) {
+		query, _, _ := querybuilder.Select().
+			From("results").
+			FullJoin("users").Using(userID).
+			CrossJoin("currencies").
+			NaturalJoin("accounts").
+			Done()
+
+		expected := "SELECT * FROM results FULL JOIN users USING (user_id) CROSS JOIN currencies NATURAL JOIN accounts;"
+		assert.Equal(t, expected, query)
+	})
+
+	test.Run("Select join without conditions", func(t *testing.T) {
+		_, _, err := querybuilder.Select().
+			From("results").
+			InnerJoin("users").On(*querybuilder.New()).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrMissingJoinCondition, err)
+
+		query := querybuilder.Select().From("results")
+		query.LeftJoin("users").As("u")
+		_, _, err = query.Done()
+
+		assert.Equal(t, querybuilder.ErrMissingJoinCondition, err)
+	})
+
+	test.Run("Select self join without qualified columns", func(t *testing.T) {
+		payments := querybuilder.NewTable("payments")
+
+		_, _, err := querybuilder.Select(id).
+			From(payments.As("a")).
+			InnerJoin(payments.As("b")).On(*querybuilder.New().Field(userID).EqualTo(userID)).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrAmbiguousTable, err)
+	})
+
+	test.Run("Select join qualifies columns with table aliases", func(t *testing.T) {
+		query, args, _ := querybuilder.Select(id, amount, querybuilder.Qualify(usersID, "manager")).
+			From("payments").As("p").
+			InnerJoin("users").On(*querybuilder.New().Field(userID).EqualTo(usersID)).
+			LeftJoin("users").As("manager").On(*querybuilder.New().Field(querybuilder.Qualify(usersID, "manager")).EqualTo(usersID)).
+			Where(*querybuilder.New().Field(usersCreated).GreaterThan(10)).
+			OrderBy(dueDate).Desc().
+			Done()
+
+		expected := "SELECT p.id, p.amount, manager.id FROM payments AS p" +
+			" INNER JOIN users ON (p.user_id = users.id)" +
+			" LEFT JOIN users AS manager ON (manager.id = users.id)" +
+			" WHERE (users.created_at > to_timestamp($1))" +
+			" ORDER BY p.due_date DESC;"
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{10}, args)
+	})
+
+	test.Run("Fields format qualifies with the field table", func(t *testing.T) {
+		assert.Equal(t, "payments.id, users.id", querybuilder.Fields{id, usersID}.Format())
+	})
+
+	test.Run("Select with several sort keys", func(t *testing.T) {
+		query, args, _ := querybuilder.Select().
+			From("results").
+			Where(*querybuilder.New().Field(userID).EqualTo("1234")).
+			OrderBy(querybuilder.Expr("COALESCE(due_date, $1)", "2020-01-01")).NullsLast().Desc().
+			OrderBy(amount).NullsFirst().Asc().
+			OrderBy(id).Asc().
+			Done()
+
+		expected := "SELECT * FROM results WHERE (user_id = $1)" +
+			" ORDER BY COALESCE(due_date, $2) DESC NULLS LAST, amount ASC NULLS FIRST, id ASC;"
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", "2020-01-01"}, args)
+	})
+
+	test.Run("Select with group by and having", func(t *testing.T) {
+		query, args, _ := querybuilder.Select(userID).
+			From("results").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true)).
+			GroupBy(userID).
+			Having(*querybuilder.New().Field(querybuilder.Expr("SUM(amount)")).GreaterThan(100)).
+			Done()
+
+		expected := "SELECT user_id FROM results WHERE (is_active = $1) GROUP BY user_id HAVING (SUM(amount) > $2);"
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true, 100}, args)
+	})
+
+	test.Run("Select with aggregates", func(t *testing.T) {
+		query, args, _ := querybuilder.Select(usersID, querybuilder.CountAll(), querybuilder.CountDistinct(dueDate), querybuilder.Max(dueDate)).
+			From("payments").
+			InnerJoin("users").On(*querybuilder.New().Field(userID).EqualTo(usersID)).
+			GroupBy(usersID).
+			Having(*querybuilder.New().
+				Field(querybuilder.Sum(amount)).GreaterThan(100).
+				And().
+				Field(querybuilder.Min(dueDate)).GreaterThan("2020-01-01")).
+			OrderBy(querybuilder.Avg(amount)).Desc().
+			Done()
+
+		expected := "SELECT users.id, COUNT(*), COUNT(DISTINCT payments.due_date), MAX(payments.due_date) FROM payments" +
+			" INNER JOIN users ON (payments.user_id = users.id)" +
+			" GROUP BY users.id" +
+			" HAVING (SUM(payments.amount) > $1 AND MIN(payments.due_date) > to_timestamp($2))" +
+			" ORDER BY AVG(payments.amount) DESC;"
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{100, "2020-01-01"}, args)
+	})
+
+	test.Run("Select distinct", func(t *testing.T) {
+		query, _, err := querybuilder.Select(userID).Distinct().From("results").Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT DISTINCT user_id FROM results;", query)
+	})
+
+	test.Run("Select distinct on", func(t *testing.T) {
+		query, args, err := querybuilder.Select(userID, amount).
+			DistinctOn(userID).
+			From("results").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true)).
+			OrderBy(userID).Asc().
+			OrderBy(dueDate).Desc().
+			Done()
+
+		expected := "SELECT DISTINCT ON (user_id) user_id, amount FROM results WHERE (is_active = $1) ORDER BY user_id ASC, due_date DESC;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true}, args)
+	})
+
+	test.Run("Select distinct on not matching the order", func(t *testing.T) {
+		_, _, err := querybuilder.Select().
+			DistinctOn(userID).
+			From("results").
+			OrderBy(dueDate).Desc().
+			OrderBy(userID).Asc().
+			Done()
+
+		assert.Equal(t, querybuilder.ErrDistinctOnOrder, err)
+	})
+
+	test.Run("Select without table", func(t *testing.T) {
+		_, _, err := querybuilder.Select().Done()
+		assert.Equal(t, querybuilder.ErrMissingTable, err)
+	})
+
+	test.Run("Select with aliases", func(t *testing.T) {
+		total := querybuilder.As(querybuilder.Sum(amount), "total")
+		s := querybuilder.Select(querybuilder.As(userID, "user"), total).
+			From("results").
+			GroupBy(userID).
+			OrderBy(total).Desc()
+
+		query, _, err := s.Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT user_id AS user, SUM(amount) AS total FROM results GROUP BY user_id ORDER BY total DESC;", query)
+		assert.Equal(t, []string{"user", "total"}, s.ResultColumns())
+	})
+
+	test.Run("Select filtered by aliases", func(t *testing.T) {
+		total := querybuilder.As(querybuilder.Sum(amount), "total")
+		query, args, err := querybuilder.Select(userID, total).
+			From("results").
+			GroupBy(userID).
+			Having(*querybuilder.New().Field(total).GreaterThan(5).Or().Field(querybuilder.Max(amount)).EqualTo(total)).
+			Done()
+
+		expected := "SELECT user_id, SUM(amount) AS total FROM results GROUP BY user_id" +
+			" HAVING (SUM(amount) > $1 OR MAX(amount) = SUM(amount));"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{5}, args)
+	})
+
+	test.Run("Select from and join subqueries", func(t *testing.T) {
+		inner := querybuilder.Select(userID, querybuilder.As(querybuilder.Sum(amount), "amount")).
+			From("payments").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true)).
+			GroupBy(userID)
+
+		latest := querybuilder.Select(userID, querybuilder.As(querybuilder.Max(dueDate), "due_date")).
+			From("payments").
+			Where(*querybuilder.New().Field(amount).GreaterThan(10)).
+			GroupBy(userID)
+
+		query, args, err := querybuilder.Select(querybuilder.Qualify(userID, "t"), querybuilder.Qualify(dueDate, "l")).
+			From(querybuilder.Subquery(inner).As("t")).
+			LeftJoin(querybuilder.Subquery(latest).As("l")).On(*querybuilder.New().
+			Field(querybuilder.Qualify(userID, "l")).EqualTo(querybuilder.Qualify(userID, "t"))).
+			Where(*querybuilder.New().Field(querybuilder.Qualify(amount, "t")).LesserThan(500)).
+			Done()
+
+		expected := "SELECT t.user_id, l.due_date" +
+			" FROM (SELECT user_id, SUM(amount) AS amount FROM payments WHERE (is_active = $1) GROUP BY user_id) AS t" +
+			" LEFT JOIN (SELECT user_id, MAX(due_date) AS due_date FROM payments WHERE (amount > $2) GROUP BY user_id) AS l" +
+			" ON (l.user_id = t.user_id)" +
+			" WHERE (t.amount < $3);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true, 10, 500}, args)
+	})
+
+	test.Run("Select from a subquery without alias", func(t *testing.T) {
+		_, _, err := querybuilder.Select().
+			From(querybuilder.Subquery(querybuilder.Select(id).From("payments"))).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrMissingAlias, err)
+	})
+
+	test.Run("Select join against an invalid table", func(t *testing.T) {
+		_, _, err := querybuilder.Select().
+			From("payments").
+			LeftJoin(42).On(*querybuilder.New().Field(amount).GreaterThan(10)).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrInvalidTable, err)
+	})
+
+	test.Run("Select from a Table", func(t *testing.T) {
+		query, _, err := querybuilder.Select(id).From(querybuilder.NewTable("payments").As("p")).Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM payments AS p;", query)
+	})
+
+	test.Run("Select with correlated exists", func(t *testing.T) {
+		payments := querybuilder.Select(id).
+			From("payments").
+			Where(*querybuilder.New().
+				Field(userID).EqualTo(usersID).
+				And().
+				Field(amount).GreaterThan(10))
+
+		query, args, err := querybuilder.Select(usersID).
+			From("users").
+			Where(*querybuilder.New().
+				Exists(payments).
+				And().
+				NotExists(querybuilder.Select(id).From("payments").Where(*querybuilder.New().Field(userID).EqualTo(usersID))).
+				And().
+				Field(usersCreated).GreaterThan("2020-01-01")).
+			Done()
+
+		expected := "SELECT id FROM users WHERE (" +
+			"EXISTS (SELECT payments.id FROM payments WHERE (payments.user_id = users.id AND payments.amount > $1))" +
+			" AND NOT EXISTS (SELECT payments.id FROM payments WHERE (payments.user_id = users.id))" +
+			" AND created_at > to_timestamp($2));"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{10, "2020-01-01"}, args)
+	})
+
+	test.Run("Select with in query and any", func(t *testing.T) {
+		active := querybuilder.Select(usersID).From("users").Where(*querybuilder.New().Field(usersCreated).GreaterThan("2020-01-01"))
+		amounts := querybuilder.Select(amount).From("payments").Where(*querybuilder.New().Field(isActive).EqualTo(false))
+
+		query, args, err := querybuilder.Select().
+			From("results").
+			Where(*querybuilder.New().
+				Field(amount).GreaterThan(5).
+				And().
+				Field(userID).InQuery(active).
+				And().
+				Field(id).NotInQuery(querybuilder.Select(id).From(querybuilder.NewTable("payments").As("archive"))).
+				And().
+				Field(amount).GreaterThan(querybuilder.All(amounts))).
+			Done()
+
+		expected := "SELECT * FROM results WHERE (amount > $1" +
+			" AND user_id IN (SELECT users.id FROM users WHERE (users.created_at > to_timestamp($2)))" +
+			" AND id NOT IN (SELECT archive.id FROM payments AS archive)" +
+			" AND amount > ALL (SELECT payments.amount FROM payments WHERE (payments.is_active = $3)));"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{5, "2020-01-01", false}, args)
+	})
+
+	test.Run("Select compared against a scalar subquery", func(t *testing.T) {
+		latest := querybuilder.Select(querybuilder.Max(amount)).
+			From("payments").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true))
+
+		query, args, err := querybuilder.Select(id).
+			From("results").
+			Where(*querybuilder.New().Field(amount).EqualTo(latest).And().Field(userID).EqualTo("1234")).
+			Done()
+
+		expected := "SELECT id FROM results" +
+			" WHERE (amount = (SELECT MAX(payments.amount) FROM payments WHERE (payments.is_active = $1)) AND user_id = $2);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true, "1234"}, args)
+	})
+
+	test.Run("Select compared against a table", func(t *testing.T) {
+		_, _, err := querybuilder.Select(id).
+			From("results").
+			Where(*querybuilder.New().Field(amount).EqualTo(querybuilder.NewTable("payments"))).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrInvalidValue, err)
+	})
+
+	test.Run("Select with nil subqueries", func(t *testing.T) {
+		filters := []*querybuilder.Filters{
+			querybuilder.New().Exists(nil),
+			querybuilder.New().NotExists(nil),
+			querybuilder.New().Field(userID).InQuery(nil),
+			querybuilder.New().Field(amount).GreaterThan(querybuilder.Any(nil)),
+			querybuilder.New().Field(amount).EqualTo(querybuilder.All(nil)),
+		}
+
+		for _, f := range filters {
+			_, _, err := querybuilder.Select().From("results").Where(*f).Done()
+			assert.Equal(t, querybuilder.ErrMissingSubquery, err)
+		}
+	})
+
+	test.Run("Select with set operations", func(t *testing.T) {
+		archived := querybuilder.Select(id, amount).
+			From("archived_payments").
+			Where(*querybuilder.New().Field(amount).GreaterThan(10))
+
+		latest := querybuilder.Select(id, amount).
+			From("payments").
+			OrderBy(dueDate).Desc().
+			Limit(5)
+
+		query, args, err := querybuilder.Select(id, amount).
+			From("payments").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true)).
+			UnionAll(archived).
+			Except(latest).
+			OrderBy(amount).Desc().
+			Limit(10).
+			Done()
+
+		expected := "SELECT id, amount FROM payments WHERE (is_active = $1)" +
+			" UNION ALL SELECT id, amount FROM archived_payments WHERE (amount > $2)" +
+			" EXCEPT (SELECT id, amount FROM payments ORDER BY due_date DESC LIMIT 5)" +
+			" ORDER BY amount DESC LIMIT 10;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true, 10}, args)
+	})
+
+	test.Run("Select with set operations sorts the result by unqualified columns", func(t *testing.T) {
+		query, _, err := querybuilder.Select(id).
+			From("payments").
+			InnerJoin("users").On(*querybuilder.New().Field(userID).EqualTo(usersID)).
+			Union(querybuilder.Select(id).From("payments")).
+			Intersect(querybuilder.Select(id).From("payments")).
+			OrderBy(id).Asc().
+			Done()
+
+		expected := "SELECT payments.id FROM payments INNER JOIN users ON (payments.user_id = users.id)" +
+			" UNION SELECT id FROM payments" +
+			" INTERSECT SELECT id FROM payments" +
+			" ORDER BY id ASC;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+	})
+
+	test.Run("Select with nested set operations", func(t *testing.T) {
+		query, _, err := querybuilder.Select(id).
+			From("a").
+			Intersect(querybuilder.Select(id).From("b").Union(querybuilder.Select(id).From("c"))).
+			Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM a INTERSECT (SELECT id FROM b UNION SELECT id FROM c);", query)
+
+		query, _, err = querybuilder.Select(id).
+			From("a").
+			Union(querybuilder.Select(id).From("b").Except(querybuilder.Select(id).From("c"))).
+			Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM a UNION (SELECT id FROM b EXCEPT SELECT id FROM c);", query)
+	})
+
+	test.Run("Select with set operations of branches with CTEs", func(t *testing.T) {
+		active := querybuilder.Select(id).From("payments").Where(*querybuilder.New().Field(isActive).EqualTo(true))
+
+		query, args, err := querybuilder.Select(id).
+			From("a").
+			Where(*querybuilder.New().Field(amount).GreaterThan(10)).
+			Union(querybuilder.With("x", active).Select(id).From("x")).
+			Done()
+
+		expected := "SELECT id FROM a WHERE (amount > $1)" +
+			" UNION (WITH x AS (SELECT id FROM payments WHERE (is_active = $2)) SELECT id FROM x);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{10, true}, args)
+	})
+
+	test.Run("Select with set operations of locked branches", func(t *testing.T) {
+		_, _, err := querybuilder.Select(id).
+			From("a").
+			Union(querybuilder.Select(id).From("b").ForUpdate().Wait()).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrLockNotAllowed, err)
+	})
+
+	test.Run("Select with set operations of different columns", func(t *testing.T) {
+		_, _, err := querybuilder.Select(id, amount).
+			From("payments").
+			Union(querybuilder.Select(id).From("archived_payments")).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrColumnCount, err)
+	})
+
+	test.Run("Select with window functions", func(t *testing.T) {
+		byUser := querybuilder.Window().PartitionBy(userID).OrderBy(dueDate).Asc()
+
+		query, args, err := querybuilder.Select(
+			id,
+			querybuilder.As(querybuilder.RowNumber().Over(byUser), "nth"),
+			querybuilder.As(querybuilder.Sum(amount).Over(querybuilder.Window().
+				PartitionBy(userID).
+				OrderBy(dueDate).NullsLast().Asc().
+				OrderBy(id).Desc().
+				Rows(querybuilder.UnboundedPreceding, querybuilder.CurrentRow)), "balance"),
+			querybuilder.Lag(amount, 1).OverWindow("w"),
+			querybuilder.DenseRank().Over(querybuilder.Window().OrderBy(amount).Desc().Range(querybuilder.Preceding(2), querybuilder.Following(3))),
+		).
+			From("payments").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true)).
+			Window("w", querybuilder.Window().PartitionBy(userID).OrderBy(dueDate).Asc()).
+			Done()
+
+		expected := "SELECT id," +
+			" ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY due_date ASC) AS nth," +
+			" SUM(amount) OVER (PARTITION BY user_id ORDER BY due_date ASC NULLS LAST, id DESC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS balance," +
+			" LAG(amount, 1) OVER w," +
+			" DENSE_RANK() OVER (ORDER BY amount DESC RANGE BETWEEN 2 PRECEDING AND 3 FOLLOWING)" +
+			" FROM payments WHERE (is_active = $1)" +
+			" WINDOW w AS (PARTITION BY user_id ORDER BY due_date ASC);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{true}, args)
+	})
+
+	test.Run("Select over undeclared windows", func(t *testing.T) {
+		_, _, err := querybuilder.Select(querybuilder.RowNumber().OverWindow("")).From("payments").Done()
+		assert.Equal(t, querybuilder.ErrUndeclaredWindow, err)
+
+		_, _, err = querybuilder.Select(id).
+			From("payments").
+			Window("w", querybuilder.Window().PartitionBy(userID)).
+			OrderBy(querybuilder.Rank().OverWindow("byUser")).Asc().
+			Done()
+
+		assert.Equal(t, querybuilder.ErrUndeclaredWindow, err)
+	})
+
+	test.Run("Select for update skip locked", func(t *testing.T) {
+		query, args, err := querybuilder.Select(id).
+			From("payments").
+			InnerJoin("users").On(*querybuilder.New().Field(userID).EqualTo(usersID)).
+			Where(*querybuilder.New().Field(isActive).EqualTo(false)).
+			Limit(10).
+			ForUpdate().Of("payments").SkipLocked().
+			ForShare().Of("users").NoWait().
+			Done()
+
+		expected := "SELECT payments.id FROM payments INNER JOIN users ON (payments.user_id = users.id)" +
+			" WHERE (payments.is_active = $1) LIMIT 10 FOR UPDATE OF payments SKIP LOCKED FOR SHARE OF users NOWAIT;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{false}, args)
+	})
+
+	test.Run("Select for no key update", func(t *testing.T) {
+		query, _, err := querybuilder.Select(id).From("payments").ForNoKeyUpdate().Wait().Done()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM payments FOR NO KEY UPDATE;", query)
+	})
+
+	test.Run("Select locking with aggregates or distinct", func(t *testing.T) {
+		_, _, err := querybuilder.Select(querybuilder.As(querybuilder.Count(id), "total")).
+			From("payments").
+			ForKeyShare().Wait().
+			Done()
+		assert.Equal(t, querybuilder.ErrLockNotAllowed, err)
+
+		_, _, err = querybuilder.Select(id).Distinct().From("payments").ForUpdate().NoWait().Done()
+		assert.Equal(t, querybuilder.ErrLockNotAllowed, err)
+	})
 }
@@ -27,21 +27,33 @@ func (s *single) addRelational(operator relationalType, values ...interface{}) *
 	return s.main
 }
 
+func (s *single) addSubquery(operator relationalType, query *sel) *Filters {
+	s.relational = &subqueryRelational{relation: operator, query: query}
+	return s.main
+}
+
 func (s *single) father() *group {
 	return s.group
 }
 
-func (s *single) format(starter int) (string, []interface{}) {
+func (s *single) format(starter int, sc *scope) (string, []interface{}) {
 	if s.relational == nil {
 		return "", nil
 	}
 
-	relation, values := s.relational.format(starter)
+	// Output aliases are not visible to the filters, they get the aliased expression
+	f := s.field
+	if a, ok := f.(Aliased); ok {
+		f = a.Field()
+	}
+
+	field, args := formatField(f, starter, sc)
+	relation, values := s.relational.format(starter+len(args), sc)
 	if relation == "" {
-		return "", values
+		return "", nil
 	}
 
-	return s.logical.format(s.field.Name() + " " + relation), values
+	return s.logical.format(field + " " + relation), append(args, values...)
 }
 
 
@@ -80,6 +92,16 @@ func (s *single) In(values ...interface{}) *Filters {
 	return s.addRelational(in, values...)
 }
 
+// InQuery adds a condition on the value being returned by the query
+func (s *single) InQuery(query *sel) *Filters {
+	return s.addSubquery(in, query)
+}
+
+// NotInQuery adds a condition on the value not being returned by the query
+func (s *single) NotInQuery(query *sel) *Filters {
+	return s.addSubquery(notIn, query)
+}
+
 // Between adds a between twe values condition
 func (s *single) Between(first interface{}, second interface{}) *Filters {
 	return s.addRelational(between, first, second)
@@ -5,20 +5,121 @@ import "strings"
 type Table struct {
 	name    string
 	as      string
+	query   *sel
+	values  *values
+	err     error
 }
 
-func (t Table) format() string {
-	if t.name == "" {
-		return ""
+// values a list of rows used as a table, its columns named after the fields
+type values struct {
+	fields Fields
+	rows   [][]interface{}
+}
+
+// NewTable creates a table to be queried
+func NewTable(name string) Table {
+	return Table{name: name}
+}
+
+// Subquery uses the result of a select as a table, it must be aliased
+func Subquery(query *sel) Table {
+	return Table{query: query}
+}
+
+// Values uses a list of rows as a table, each row having a value for each field. The
+// values get formatted with the field types and the columns named after the fields, the
+// table must be aliased
+func Values(fields Fields, rows ...[]interface{}) Table {
+	return Table{values: &values{fields: fields, rows: rows}}
+}
+
+type tables []Table
+
+// format returns the tables separated by commas numbering the placeholders from starter
+func (t tables) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	formatted := make([]string, len(t))
+	for i, table := range t {
+		tableFormatted, tableArgs := table.format(starter+len(args), sc)
+		formatted[i] = tableFormatted
+		args = append(args, tableArgs...)
+	}
+
+	return strings.Join(formatted, ", "), args
+}
+
+// toTables turns every source into a Table
+func toTables(sources ...interface{}) tables {
+	t := make(tables, len(sources))
+	for i, source := range sources {
+		t[i] = toTable(source)
+	}
+
+	return t
+}
+
+// toTable turns a table name, a Table or a select into a Table, anything else into one
+// failing when formatted
+func toTable(source interface{}) Table {
+	switch t := source.(type) {
+	case string:
+		return NewTable(t)
+	case Table:
+		return t
+	case *Table:
+		return *t
+	case *sel:
+		return Subquery(t)
+	}
+
+	return Table{err: ErrInvalidTable}
+}
+
+// As sets the alias of the table
+func (t Table) As(as string) Table {
+	t.as = as
+	return t
+}
+
+func (t Table) empty() bool {
+	return t.name == "" && t.query == nil && t.values == nil && t.err == nil
+}
+
+func (t Table) format(starter int, sc *scope) (string, []interface{}) {
+	if t.empty() {
+		return "", nil
+	}
+
+	if t.err != nil {
+		sc.fail(t.err)
+		return "", nil
+	}
+
+	if (t.query != nil || t.values != nil) && t.as == "" {
+		sc.fail(ErrMissingAlias)
 	}
 
-	str := strings.ToLower(t.name)
+	var args []interface{}
+	var str string
+	switch {
+	case t.query != nil:
+		str, args = t.query.nested(starter, sc.detached())
+	case t.values != nil:
+		str, args = formatRows(t.values.fields, t.values.rows, true, starter, sc.detached())
+		str = "(VALUES " + str + ")"
+	default:
+		str = strings.ToLower(t.name)
+	}
 
 	if t.as != "" {
 		str += " AS " + t.as
 	}
 
-	return str
+	if t.values != nil {
+		str += " (" + strings.Join(t.values.fields.Names(), ", ") + ")"
+	}
+
+	return str, args
 }
 
 func (t Table) prefix() string {
@@ -26,5 +127,5 @@ func (t Table) prefix() string {
 		return t.as
 	}
 
-	return t.name
+	return strings.ToLower(t.name)
 }
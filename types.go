@@ -28,6 +28,52 @@ func (f Type) format(value string) string {
 	return format(value)
 }
 
+// formatValue formats value as a placeholder of the given type. Fields and expressions
+// are not bound as arguments but written as they are, selects between parentheses
+func formatValue(value interface{}, t Type, starter int, sc *scope) (string, []interface{}) {
+	switch v := value.(type) {
+	case *sel:
+		return v.nested(starter, sc)
+	case Aliased:
+		return formatValue(v.Field(), t, starter, sc)
+	case *expr, *aggregate, *windowed, *excluded, *quantified, defaultValue:
+		return v.(expression).format(starter, sc)
+	case Field:
+		return sc.reference(v), nil
+	case expression:
+		sc.fail(ErrInvalidValue)
+		return "", nil
+	}
+
+	return t.format(fmt.Sprintf("$%v", starter)), []interface{}{value}
+}
+
+// formatCastValue formats value like formatValue, casting the placeholder to the type
+func formatCastValue(value interface{}, t Type, starter int, sc *scope) (string, []interface{}) {
+	switch value.(type) {
+	case Field, expression:
+		return formatValue(value, t, starter, sc)
+	}
+
+	return t.cast(t.format(fmt.Sprintf("$%v", starter))), []interface{}{value}
+}
+
+// cast casts a placeholder to the type. Strings are what untyped placeholders resolve to
+// and dates are already typed by their format
+func (f Type) cast(value string) string {
+	casts := map[Type]string{
+		Numeric: "numeric",
+		Bool:    "boolean",
+	}
+
+	cast, found := casts[f]
+	if !found {
+		return value
+	}
+
+	return value + "::" + cast
+}
+
 type formatter func(value string) string
 
 func dateFormat(value string) string   { return fmt.Sprintf("to_timestamp(%s)", value) }
@@ -0,0 +1,110 @@
+package querybuilder
+
+type update struct {
+	baseQuery
+	returning
+	sets   assignments
+	from   tables
+	filter *Filters
+	all    bool
+}
+
+// Set sets field to value, formatted with the field type
+func (u *update) Set(field Field, value interface{}) *update {
+	u.sets = append(u.sets, &assignment{field: field, value: value})
+	return u
+}
+
+// SetExpr sets field to the result of an expression, such as Expr("amount + $1", 10)
+func (u *update) SetExpr(field Field, expr Field) *update {
+	u.sets = append(u.sets, &assignment{field: field, value: expr})
+	return u
+}
+
+// From adds tables to read from, either table names, Tables, Subqueries or Values. Their
+// rows are joined to the updated ones through the Where filters
+func (u *update) From(sources ...interface{}) *update {
+	u.from = append(u.from, toTables(sources...)...)
+	return u
+}
+
+// Where updates only the rows matching the filters
+func (u *update) Where(filters Filters) *update {
+	u.filter = &filters
+	return u
+}
+
+// AllRows allows the query to update every row, as it fails without filters otherwise
+func (u *update) AllRows() *update {
+	u.all = true
+	return u
+}
+
+// Returning gives back the given fields of the updated rows
+func (u *update) Returning(fields ...Field) *update {
+	u.returning.add(fields...)
+	return u
+}
+
+// Done returns the query and the arguments to be bound to its placeholders
+func (u *update) Done() (string, []interface{}, error) {
+	return done(u)
+}
+
+// format builds the query numbering its placeholders from starter
+func (u *update) format(starter int, parent *scope) (string, []interface{}) {
+	if !u.hasTable() {
+		parent.fail(ErrMissingTable)
+		return "", nil
+	}
+
+	if len(u.sets) == 0 {
+		parent.fail(ErrMissingSet)
+	}
+
+	sc := newScope(parent, append(tables{u.table}, u.from...)...)
+	query, args := u.with.format(starter, sc)
+	table, tableArgs := u.table.format(starter+len(args), sc)
+	query += "UPDATE " + table
+	args = append(args, tableArgs...)
+
+	sets, setArgs := u.sets.format(starter+len(args), sc)
+	query += " SET " + sets
+	args = append(args, setArgs...)
+
+	if len(u.from) > 0 {
+		from, fromArgs := u.from.format(starter+len(args), sc)
+		query += " FROM " + from
+		args = append(args, fromArgs...)
+	}
+
+	where, whereArgs := formatWhere(u.filter, u.all, starter+len(args), sc)
+	query += where
+	args = append(args, whereArgs...)
+
+	returned, returnedArgs := u.returning.format(starter+len(args), sc)
+	query += returned
+	args = append(args, returnedArgs...)
+
+	return query, args
+}
+
+// formatWhere formats the WHERE clause of a statement changing rows. Unless every row
+// is allowed to change, the filters can not be empty to not affect the whole table
+func formatWhere(filter *Filters, all bool, starter int, sc *scope) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	if filter != nil {
+		where, args = filter.format(starter, sc)
+	}
+
+	if where == "" {
+		if !all {
+			sc.fail(ErrMissingWhere)
+		}
+
+		return "", nil
+	}
+
+	return " WHERE " + where, args
+}
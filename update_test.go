@@ -0,0 +1,121 @@
+package querybuilder_test
+
+import (
+	"testing"
+
+	"github.com/gonzispina/querybuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateQuery(test *testing.T) {
+	payments := querybuilder.NewTable("payments")
+
+	test.Run("Update with filters", func(t *testing.T) {
+		query, args, err := querybuilder.Update(payments).
+			Set(dueDate, "2020-01-01").
+			Set(isActive, false).
+			SetExpr(amount, querybuilder.Expr("amount + $1", 10)).
+			Where(*querybuilder.New().Field(userID).EqualTo("1234").And().Field(amount).GreaterThan(5)).
+			Done()
+
+		expected := "UPDATE payments SET due_date = to_timestamp($1), is_active = $2, amount = amount + $3" +
+			" WHERE (user_id = $4 AND amount > $5);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"2020-01-01", false, 10, "1234", 5}, args)
+	})
+
+	test.Run("Update with common table expressions returning", func(t *testing.T) {
+		late := querybuilder.Select(id).From("payments").Where(*querybuilder.New().Field(dueDate).LesserThan("2020-01-01"))
+
+		update := querybuilder.With("late", late).
+			Update(payments).
+			Set(isActive, false).
+			Where(*querybuilder.New().Field(id).InQuery(querybuilder.Select(querybuilder.Qualify(id, "late")).From("late"))).
+			Returning(id, amount)
+
+		query, args, err := update.Done()
+
+		expected := "WITH late AS (SELECT id FROM payments WHERE (due_date < to_timestamp($1)))" +
+			" UPDATE payments SET is_active = $2" +
+			" WHERE (id IN (SELECT late.id FROM late))" +
+			" RETURNING id, amount;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"2020-01-01", false}, args)
+		assert.Equal(t, []string{"id", "amount"}, update.ResultColumns())
+	})
+
+	test.Run("Update without columns to set", func(t *testing.T) {
+		_, _, err := querybuilder.Update(payments).Done()
+		assert.Equal(t, querybuilder.ErrMissingSet, err)
+	})
+
+	test.Run("Update from values of numeric and bool columns", func(t *testing.T) {
+		changes := querybuilder.Values(querybuilder.Fields{id, amount, isActive},
+			[]interface{}{"1234", 10, true},
+			[]interface{}{"5678", querybuilder.Expr("$1 * 2", 20), false},
+		).As("v")
+
+		query, args, err := querybuilder.Update(payments).
+			Set(amount, querybuilder.Qualify(amount, "v")).
+			Set(isActive, querybuilder.Qualify(isActive, "v")).
+			From(changes).
+			Where(*querybuilder.New().Field(id).EqualTo(querybuilder.Qualify(id, "v"))).
+			Done()
+
+		expected := "UPDATE payments SET amount = v.amount, is_active = v.is_active" +
+			" FROM (VALUES ($1, $2::numeric, $3::boolean), ($4, $5 * 2, $6::boolean)) AS v (id, amount, is_active)" +
+			" WHERE (payments.id = v.id);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", 10, true, "5678", 20, false}, args)
+	})
+
+	test.Run("Update from values without alias", func(t *testing.T) {
+		_, _, err := querybuilder.Update(payments).
+			Set(dueDate, querybuilder.Qualify(dueDate, "changes")).
+			From(querybuilder.Values(querybuilder.Fields{id, dueDate}, []interface{}{"1234", "2020-01-01"})).
+			Where(*querybuilder.New().Field(id).EqualTo(querybuilder.Qualify(id, "changes"))).
+			Done()
+
+		assert.Equal(t, querybuilder.ErrMissingAlias, err)
+	})
+
+	test.Run("Update from other tables", func(t *testing.T) {
+		changes := querybuilder.Values(querybuilder.Fields{id, dueDate},
+			[]interface{}{"1234", "2020-01-01"},
+			[]interface{}{"5678", "2021-01-01"},
+		).As("changes")
+
+		query, args, err := querybuilder.Update(payments).
+			Set(dueDate, querybuilder.Qualify(dueDate, "changes")).
+			Set(amount, querybuilder.Qualify(amount, "staging")).
+			From(changes, querybuilder.NewTable("payments_staging").As("staging")).
+			Where(*querybuilder.New().
+				Field(id).EqualTo(querybuilder.Qualify(id, "changes")).
+				And().
+				Field(id).EqualTo(querybuilder.Qualify(id, "staging")).
+				And().
+				Field(isActive).EqualTo(true)).
+			Done()
+
+		expected := "UPDATE payments SET due_date = changes.due_date, amount = staging.amount" +
+			" FROM (VALUES ($1, to_timestamp($2)), ($3, to_timestamp($4))) AS changes (id, due_date), payments_staging AS staging" +
+			" WHERE (payments.id = changes.id AND payments.id = staging.id AND payments.is_active = $5);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234", "2020-01-01", "5678", "2021-01-01", true}, args)
+	})
+
+	test.Run("Update without filters", func(t *testing.T) {
+		_, _, err := querybuilder.Update(payments).Set(isActive, false).Done()
+		assert.Equal(t, querybuilder.ErrMissingWhere, err)
+
+		query, args, err := querybuilder.Update(payments).Set(isActive, false).AllRows().Done()
+		assert.NoError(t, err)
+		assert.Equal(t, "UPDATE payments SET is_active = $1;", query)
+		assert.Equal(t, []interface{}{false}, args)
+	})
+}
@@ -0,0 +1,221 @@
+package querybuilder
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RowNumber numbers the rows of the window starting from 1
+func RowNumber() *aggregate {
+	return newAggregate("ROW_NUMBER", nil, Numeric)
+}
+
+// Rank ranks the rows of the window leaving gaps for the peers
+func Rank() *aggregate {
+	return newAggregate("RANK", nil, Numeric)
+}
+
+// DenseRank ranks the rows of the window without gaps
+func DenseRank() *aggregate {
+	return newAggregate("DENSE_RANK", nil, Numeric)
+}
+
+// Lag retrieves field from the row offset rows before the current one
+func Lag(field Field, offset int) *aggregate {
+	a := newAggregate("LAG", field, field.Type())
+	a.arguments = []string{strconv.Itoa(offset)}
+	return a
+}
+
+// Lead retrieves field from the row offset rows after the current one
+func Lead(field Field, offset int) *aggregate {
+	a := newAggregate("LEAD", field, field.Type())
+	a.arguments = []string{strconv.Itoa(offset)}
+	return a
+}
+
+// windowed a function computed over a window, either defined in place or named in the query
+type windowed struct {
+	function *aggregate
+	window   *window
+	name     string
+}
+
+// Name retrieves the function expression
+func (w *windowed) Name() string {
+	return w.function.Name()
+}
+
+// Table retrieves white, the result does not belong to any table
+func (w *windowed) Table() string {
+	return ""
+}
+
+// Type retrieves the type of the function result
+func (w *windowed) Type() Type {
+	return w.function.Type()
+}
+
+func (w *windowed) format(starter int, sc *scope) (string, []interface{}) {
+	function, args := w.function.format(starter, sc)
+	if w.window == nil {
+		return function + " OVER " + w.name, args
+	}
+
+	window, windowArgs := w.window.format(starter+len(args), sc)
+	return function + " OVER (" + window + ")", append(args, windowArgs...)
+}
+
+// FrameBound a bound of a window frame
+type FrameBound string
+
+const (
+	// UnboundedPreceding the frame starts at the first row of the partition
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	// CurrentRow the frame starts or ends at the current row
+	CurrentRow FrameBound = "CURRENT ROW"
+	// UnboundedFollowing the frame ends at the last row of the partition
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding the frame starts or ends offset rows before the current one
+func Preceding(offset int) FrameBound {
+	return FrameBound(strconv.Itoa(offset) + " PRECEDING")
+}
+
+// Following the frame starts or ends offset rows after the current one
+func Following(offset int) FrameBound {
+	return FrameBound(strconv.Itoa(offset) + " FOLLOWING")
+}
+
+type frameType string
+
+const (
+	rows   frameType = "ROWS"
+	ranges frameType = "RANGE"
+)
+
+type frame struct {
+	t     frameType
+	start FrameBound
+	end   FrameBound
+}
+
+func (f *frame) format() string {
+	return string(f.t) + " BETWEEN " + string(f.start) + " AND " + string(f.end)
+}
+
+// Window creates a window definition
+func Window() *window {
+	return &window{}
+}
+
+type window struct {
+	partition Fields
+	orders    orders
+	frame     *frame
+}
+
+// PartitionBy splits the rows in windows sharing the given fields
+func (w *window) PartitionBy(fields ...Field) *window {
+	w.partition = append(w.partition, fields...)
+	return w
+}
+
+// OrderBy adds a sort key to the rows of the window
+func (w *window) OrderBy(column Field) *windowOrder {
+	o := &windowOrder{
+		order:  &order{column: column},
+		father: w,
+	}
+
+	w.orders = append(w.orders, o.order)
+	return o
+}
+
+// Rows sets the frame as the rows between start and end
+func (w *window) Rows(start FrameBound, end FrameBound) *window {
+	w.frame = &frame{t: rows, start: start, end: end}
+	return w
+}
+
+// Range sets the frame as the rows whose values are between start and end
+func (w *window) Range(start FrameBound, end FrameBound) *window {
+	w.frame = &frame{t: ranges, start: start, end: end}
+	return w
+}
+
+// format returns the window definition without its parentheses
+func (w *window) format(starter int, sc *scope) (string, []interface{}) {
+	var args []interface{}
+	var parts []string
+	if len(w.partition) > 0 {
+		partition, partitionArgs := w.partition.format(starter, sc)
+		parts = append(parts, "PARTITION BY "+partition)
+		args = append(args, partitionArgs...)
+	}
+
+	ordered, orderArgs := w.orders.format(starter+len(args), sc)
+	if ordered != "" {
+		parts = append(parts, strings.TrimPrefix(ordered, " "))
+		args = append(args, orderArgs...)
+	}
+
+	if w.frame != nil {
+		parts = append(parts, w.frame.format())
+	}
+
+	return strings.Join(parts, " "), args
+}
+
+// windowOrder a sort key of a window
+type windowOrder struct {
+	*order
+	father *window
+}
+
+func (o *windowOrder) Asc() *window {
+	o.t = asc
+	return o.father
+}
+
+func (o *windowOrder) Desc() *window {
+	o.t = desc
+	return o.father
+}
+
+// NullsFirst sorts the null values before the rest
+func (o *windowOrder) NullsFirst() *windowOrder {
+	o.nulls = nullsFirst
+	return o
+}
+
+// NullsLast sorts the null values after the rest
+func (o *windowOrder) NullsLast() *windowOrder {
+	o.nulls = nullsLast
+	return o
+}
+
+// namedWindow a window defined in the WINDOW clause of a query
+type namedWindow struct {
+	name   string
+	window *window
+}
+
+type namedWindows []*namedWindow
+
+func (n namedWindows) format(starter int, sc *scope) (string, []interface{}) {
+	if len(n) == 0 {
+		return "", nil
+	}
+
+	var args []interface{}
+	formatted := make([]string, len(n))
+	for i, w := range n {
+		window, windowArgs := w.window.format(starter+len(args), sc)
+		formatted[i] = w.name + " AS (" + window + ")"
+		args = append(args, windowArgs...)
+	}
+
+	return " WINDOW " + strings.Join(formatted, ", "), args
+}
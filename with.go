@@ -0,0 +1,130 @@
+package querybuilder
+
+import "strings"
+
+type materializedType string
+
+const (
+	materialized    materializedType = "MATERIALIZED"
+	notMaterialized materializedType = "NOT MATERIALIZED"
+)
+
+// With starts a statement prefixed by the common table expression name
+func With(name string, q Query) *with {
+	return (&with{}).With(name, q)
+}
+
+// WithRecursive starts a statement prefixed by the recursive common table expression name,
+// the anchor rows joined through UNION ALL to the ones the recursive query finds from them
+func WithRecursive(name string, anchor Query, recursive Query) *with {
+	return (&with{}).WithRecursive(name, anchor, recursive)
+}
+
+type cte struct {
+	name         string
+	query        Query
+	recursive    Query
+	materialized materializedType
+}
+
+func (c *cte) format(starter int, sc *scope) (string, []interface{}) {
+	query, args := c.query.format(starter, sc.detached())
+	if c.recursive != nil {
+		recursive, recursiveArgs := c.recursive.format(starter+len(args), sc.detached())
+		query += " UNION ALL " + recursive
+		args = append(args, recursiveArgs...)
+	}
+
+	str := c.name + " AS "
+	if c.materialized != "" {
+		str += string(c.materialized) + " "
+	}
+
+	return str + "(" + query + ")", args
+}
+
+type with struct {
+	ctes []*cte
+}
+
+// With adds another common table expression
+func (w *with) With(name string, q Query) *with {
+	w.ctes = append(w.ctes, &cte{name: name, query: q})
+	return w
+}
+
+// WithRecursive adds another recursive common table expression
+func (w *with) WithRecursive(name string, anchor Query, recursive Query) *with {
+	w.ctes = append(w.ctes, &cte{name: name, query: anchor, recursive: recursive})
+	return w
+}
+
+// Materialized forces the last common table expression to be computed once
+func (w *with) Materialized() *with {
+	return w.materialize(materialized)
+}
+
+// NotMaterialized lets the last common table expression be folded into the statement
+func (w *with) NotMaterialized() *with {
+	return w.materialize(notMaterialized)
+}
+
+func (w *with) materialize(t materializedType) *with {
+	if len(w.ctes) > 0 {
+		w.ctes[len(w.ctes)-1].materialized = t
+	}
+
+	return w
+}
+
+// Select initiates a new select query prefixed by the common table expressions
+func (w *with) Select(fields ...Field) *sel {
+	s := Select(fields...)
+	s.with = w
+	return s
+}
+
+// InsertInto initiates a new insert query prefixed by the common table expressions
+func (w *with) InsertInto(table Table) *insert {
+	i := InsertInto(table)
+	i.with = w
+	return i
+}
+
+// Update initiates a new update query prefixed by the common table expressions
+func (w *with) Update(table Table) *update {
+	u := Update(table)
+	u.with = w
+	return u
+}
+
+// DeleteFrom initiates a new delete query prefixed by the common table expressions
+func (w *with) DeleteFrom(table Table) *delete {
+	d := DeleteFrom(table)
+	d.with = w
+	return d
+}
+
+// format returns the WITH clause, followed by a space, numbering the placeholders from starter
+func (w *with) format(starter int, sc *scope) (string, []interface{}) {
+	if w == nil || len(w.ctes) == 0 {
+		return "", nil
+	}
+
+	var args []interface{}
+	recursive := false
+	formatted := make([]string, len(w.ctes))
+	for i, c := range w.ctes {
+		cteFormatted, cteArgs := c.format(starter+len(args), sc)
+		formatted[i] = cteFormatted
+		args = append(args, cteArgs...)
+		recursive = recursive || c.recursive != nil
+	}
+
+	str := "WITH "
+	if recursive {
+		str += "RECURSIVE "
+	}
+
+	return str + strings.Join(formatted, ", ") + " ", args
+}
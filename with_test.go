@@ -0,0 +1,75 @@
+package querybuilder_test
+
+import (
+	"testing"
+
+	"github.com/gonzispina/querybuilder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQuery(test *testing.T) {
+	test.Run("Select with common table expressions", func(t *testing.T) {
+		active := querybuilder.Select(usersID).
+			From("users").
+			Where(*querybuilder.New().Field(usersCreated).GreaterThan("2020-01-01"))
+
+		big := querybuilder.Select(userID, amount).
+			From("payments").
+			Where(*querybuilder.New().Field(amount).GreaterThan(100))
+
+		query, args, err := querybuilder.With("active", active).
+			With("big", big).Materialized().
+			Select(userID).
+			From("big").
+			Where(*querybuilder.New().Field(isActive).EqualTo(true)).
+			Done()
+
+		expected := "WITH active AS (SELECT id FROM users WHERE (created_at > to_timestamp($1)))," +
+			" big AS MATERIALIZED (SELECT user_id, amount FROM payments WHERE (amount > $2))" +
+			" SELECT user_id FROM big WHERE (is_active = $3);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"2020-01-01", 100, true}, args)
+	})
+
+	test.Run("Select with recursive common table expression", func(t *testing.T) {
+		anchor := querybuilder.Select(usersID).
+			From("users").
+			Where(*querybuilder.New().Field(usersID).EqualTo("1234"))
+
+		recursive := querybuilder.Select(id).
+			From("payments").
+			InnerJoin("tree").On(*querybuilder.New().Field(userID).EqualTo(querybuilder.Qualify(usersID, "tree")))
+
+		query, args, err := querybuilder.WithRecursive("tree", anchor, recursive).NotMaterialized().
+			Select().
+			From("tree").
+			Done()
+
+		expected := "WITH RECURSIVE tree AS NOT MATERIALIZED (SELECT id FROM users WHERE (id = $1)" +
+			" UNION ALL SELECT payments.id FROM payments INNER JOIN tree ON (payments.user_id = tree.id))" +
+			" SELECT * FROM tree;"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"1234"}, args)
+	})
+
+	test.Run("Insert with common table expressions", func(t *testing.T) {
+		active := querybuilder.Select(usersID).
+			From("users").
+			Where(*querybuilder.New().Field(usersCreated).GreaterThan("2020-01-01"))
+
+		query, args, err := querybuilder.With("active", active).
+			InsertInto(querybuilder.NewTable("payments")).
+			Columns(id, amount).
+			Values("1234", 10).
+			Done()
+
+		expected := "WITH active AS (SELECT id FROM users WHERE (created_at > to_timestamp($1)))" +
+			" INSERT INTO payments (id, amount) VALUES ($2, $3);"
+		assert.NoError(t, err)
+		assert.Equal(t, expected, query)
+		assert.Equal(t, []interface{}{"2020-01-01", "1234", 10}, args)
+	})
+}